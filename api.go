@@ -0,0 +1,29 @@
+package endoflife
+
+import "context"
+
+// API is the set of queries supported by the endoflife.date API.
+//
+// It is implemented by *Client, which queries the API over HTTP, and by
+// *Snapshot, which answers from a local copy. Code that depends on API
+// rather than *Client can be given fakes in tests or wrapped with
+// decorators, for example to add metrics.
+type API interface {
+	GetIndex(ctx context.Context) (*URIListResponse, error)
+	GetProducts(ctx context.Context) (*ProductListResponse, error)
+	GetProductsFull(ctx context.Context) (*FullProductListResponse, error)
+	GetProduct(ctx context.Context, productName string) (*ProductResponse, error)
+	GetRelease(ctx context.Context, productName, releaseName string) (*ProductReleaseResponse, error)
+	GetLatestRelease(ctx context.Context, productName string) (*ProductReleaseResponse, error)
+	GetCategories(ctx context.Context) (*URIListResponse, error)
+	GetCategoryProducts(ctx context.Context, categoryName string) (*ProductListResponse, error)
+	GetTags(ctx context.Context) (*URIListResponse, error)
+	GetTagProducts(ctx context.Context, tagName string) (*ProductListResponse, error)
+	GetIdentifiers(ctx context.Context) (*URIListResponse, error)
+	GetIdentifierDetails(ctx context.Context, identifierType string) (*IdentifierListResponse, error)
+}
+
+var (
+	_ API = (*Client)(nil)
+	_ API = (*Snapshot)(nil)
+)
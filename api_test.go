@@ -0,0 +1,33 @@
+package endoflife
+
+import (
+	"context"
+	"testing"
+)
+
+// countingAPI is a decorator that counts product lookups.
+type countingAPI struct {
+	API
+	productCalls int
+}
+
+func (a *countingAPI) GetProduct(ctx context.Context, productName string) (*ProductResponse, error) {
+	a.productCalls++
+	return a.API.GetProduct(ctx, productName)
+}
+
+func TestAPI_Decorator(t *testing.T) {
+	var api API = &countingAPI{API: testSnapshot()}
+	ctx := context.Background()
+
+	if _, err := api.GetProduct(ctx, "python"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := api.GetRelease(ctx, "python", "3.12"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls := api.(*countingAPI).productCalls; calls != 1 {
+		t.Errorf("expected 1 product call, got %d", calls)
+	}
+}
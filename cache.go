@@ -0,0 +1,185 @@
+package endoflife
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the number of entries kept by the in-memory cache
+// used for conditional requests.
+const DefaultCacheSize = 256
+
+// CacheEntry is a cached API response.
+type CacheEntry struct {
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	StoredAt     time.Time `json:"stored_at"`
+}
+
+// Cache stores API responses keyed by request URL.
+//
+// Implementations must be safe for concurrent use. Caching is best-effort:
+// a Cache that fails to store or load an entry behaves as if it were absent.
+type Cache interface {
+	// Get returns the entry for key, if any.
+	Get(key string) (*CacheEntry, bool)
+
+	// Set stores the entry for key.
+	Set(key string, entry *CacheEntry)
+
+	// Delete removes the entry for key.
+	Delete(key string)
+}
+
+// MemoryCache is an in-memory Cache that evicts the least recently used
+// entries once it holds more than its maximum number of entries.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache creates a MemoryCache holding at most maxEntries entries.
+// A maxEntries of zero or less means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*memoryCacheItem).entry, true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, entry *CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*memoryCacheItem).entry = entry
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&memoryCacheItem{key: key, entry: entry})
+	if c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Delete implements Cache.
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.ll.Remove(el)
+		delete(c.items, key)
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// FileCache is a Cache that stores each entry as a JSON file in a directory,
+// so that cached responses survive across processes.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache creates a FileCache storing entries in dir. The directory is
+// created on the first write.
+func NewFileCache(dir string) *FileCache {
+	return &FileCache{dir: dir}
+}
+
+// DefaultCacheDir returns the default directory for a FileCache, located in
+// the user's cache directory.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "endoflife-go"), nil
+}
+
+// Get implements Cache.
+func (c *FileCache) Get(key string) (*CacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Set implements Cache.
+func (c *FileCache) Set(key string, entry *CacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return
+	}
+
+	// Write to a temporary file first so that concurrent readers never
+	// observe a partially written entry.
+	f, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return
+	}
+	if err := f.Close(); err != nil {
+		return
+	}
+	os.Rename(f.Name(), c.path(key))
+}
+
+// Delete implements Cache.
+func (c *FileCache) Delete(key string) {
+	os.Remove(c.path(key))
+}
+
+// path returns the file path for key.
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
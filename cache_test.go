@@ -0,0 +1,151 @@
+package endoflife
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache_LRU(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", &CacheEntry{Body: []byte("a")})
+	cache.Set("b", &CacheEntry{Body: []byte("b")})
+
+	// Touch "a" so that "b" becomes the least recently used entry.
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected entry a")
+	}
+	cache.Set("c", &CacheEntry{Body: []byte("c")})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected entry b to be evicted")
+	}
+	if entry, ok := cache.Get("a"); !ok || string(entry.Body) != "a" {
+		t.Error("expected entry a to be kept")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+
+	cache.Delete("a")
+	if _, ok := cache.Get("a"); ok {
+		t.Error("expected entry a to be deleted")
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	storedAt := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	NewFileCache(dir).Set("https://example.com/products/python", &CacheEntry{
+		Body:     []byte(`{"result":{}}`),
+		ETag:     `"v1"`,
+		StoredAt: storedAt,
+	})
+
+	// A new instance sees entries written by another one.
+	entry, ok := NewFileCache(dir).Get("https://example.com/products/python")
+	if !ok {
+		t.Fatal("expected cached entry")
+	}
+	if string(entry.Body) != `{"result":{}}` {
+		t.Errorf("unexpected body: %s", entry.Body)
+	}
+	if entry.ETag != `"v1"` {
+		t.Errorf("unexpected ETag: %s", entry.ETag)
+	}
+	if !entry.StoredAt.Equal(storedAt) {
+		t.Errorf("unexpected StoredAt: %v", entry.StoredAt)
+	}
+
+	if _, ok := NewFileCache(dir).Get("https://example.com/products/go"); ok {
+		t.Error("expected no entry for another key")
+	}
+
+	NewFileCache(dir).Delete("https://example.com/products/python")
+	if _, ok := NewFileCache(dir).Get("https://example.com/products/python"); ok {
+		t.Error("expected entry to be deleted")
+	}
+}
+
+func TestClientCache_Fresh(t *testing.T) {
+	var calls atomic.Int32
+	client, server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"result":{"name":"python"}}`))
+	})
+	defer server.Close()
+	WithCache(NewMemoryCache(0), time.Hour)(client)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		result, err := client.GetProduct(ctx, "python")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Result.Name != "python" {
+			t.Errorf("expected product name 'python', got %s", result.Result.Name)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+}
+
+func TestClientCache_Revalidate(t *testing.T) {
+	cache := NewMemoryCache(0)
+	client, server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != `"v1"` {
+			t.Errorf("unexpected If-None-Match header: %s", r.Header.Get("If-None-Match"))
+		}
+		w.WriteHeader(http.StatusNotModified)
+	})
+	defer server.Close()
+	WithCache(cache, time.Minute)(client)
+
+	key := server.URL + "/products/python"
+	stale := time.Now().Add(-time.Hour)
+	cache.Set(key, &CacheEntry{Body: []byte(`{"result":{"name":"python"}}`), ETag: `"v1"`, StoredAt: stale})
+
+	result, err := client.GetProduct(context.Background(), "python")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Result.Name != "python" {
+		t.Errorf("expected product name 'python', got %s", result.Result.Name)
+	}
+
+	entry, _ := cache.Get(key)
+	if !entry.StoredAt.After(stale) {
+		t.Error("expected revalidated entry to be refreshed")
+	}
+}
+
+func TestClientCache_StaleIfError(t *testing.T) {
+	cache := NewMemoryCache(0)
+	client, server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+	WithCache(cache, time.Minute)(client)
+
+	cache.Set(server.URL+"/products/python", &CacheEntry{
+		Body:     []byte(`{"result":{"name":"python"}}`),
+		StoredAt: time.Now().Add(-time.Hour),
+	})
+
+	result, err := client.GetProduct(context.Background(), "python")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Result.Name != "python" {
+		t.Errorf("expected product name 'python', got %s", result.Result.Name)
+	}
+
+	// Errors that are not outages are still reported.
+	if _, err := client.GetProduct(context.Background(), "go"); err == nil {
+		t.Error("expected error for uncached product")
+	}
+}
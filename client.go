@@ -7,7 +7,6 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"strconv"
 	"time"
 )
 
@@ -32,6 +31,16 @@ type Client struct {
 
 	// UserAgent is the User-Agent to set on requests.
 	UserAgent string
+
+	// Retry is the retry policy for failed requests. Nil disables retries.
+	Retry *RetryPolicy
+
+	// Cache stores responses for reuse. Nil disables caching.
+	Cache Cache
+
+	// CacheTTL is how long a cached response is served without contacting
+	// the API. Older responses are revalidated with a conditional request.
+	CacheTTL time.Duration
 }
 
 // NewClient creates a new Client with default settings.
@@ -69,6 +78,33 @@ func WithUserAgent(userAgent string) Option {
 	}
 }
 
+// WithRetry enables automatic retries using the given policy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.Retry = &policy
+	}
+}
+
+// WithConditionalRequests enables conditional requests. The client remembers
+// the ETag and Last-Modified validators of each response and sends them back
+// as If-None-Match and If-Modified-Since. When the API answers 304 Not
+// Modified, the previously received body is returned.
+//
+// It is equivalent to WithCache(NewMemoryCache(DefaultCacheSize), 0).
+func WithConditionalRequests() Option {
+	return WithCache(NewMemoryCache(DefaultCacheSize), 0)
+}
+
+// WithCache sets the response cache. Cached responses younger than ttl are
+// served without contacting the API; older ones are revalidated with a
+// conditional request, and served as-is if the API is unavailable.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.Cache = cache
+		c.CacheTTL = ttl
+	}
+}
+
 // NewClientWithOptions creates a new Client with the given options.
 func NewClientWithOptions(opts ...Option) *Client {
 	c := NewClient()
@@ -85,34 +121,110 @@ func (c *Client) doRequest(ctx context.Context, method, path string, result any)
 		return fmt.Errorf("failed to build URL: %w", err)
 	}
 
+	body, err := c.get(ctx, method, reqURL)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// get returns the response body for reqURL, consulting c.Cache if set.
+func (c *Client) get(ctx context.Context, method, reqURL string) ([]byte, error) {
+	if c.Cache == nil {
+		entry, err := c.fetch(ctx, method, reqURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		return entry.Body, nil
+	}
+
+	cached, ok := c.Cache.Get(reqURL)
+	if !ok {
+		cached = nil
+	}
+	if cached != nil && c.CacheTTL > 0 && time.Since(cached.StoredAt) < c.CacheTTL {
+		return cached.Body, nil
+	}
+
+	entry, err := c.fetch(ctx, method, reqURL, cached)
+	if err != nil {
+		// Serve stale data while the API is unavailable.
+		if cached != nil && isRetryable(err) {
+			return cached.Body, nil
+		}
+		return nil, err
+	}
+
+	c.Cache.Set(reqURL, entry)
+	return entry.Body, nil
+}
+
+// fetch executes an HTTP request, retrying it according to c.Retry.
+func (c *Client) fetch(ctx context.Context, method, reqURL string, cached *CacheEntry) (*CacheEntry, error) {
+	if c.Retry == nil {
+		return c.fetchOnce(ctx, method, reqURL, cached)
+	}
+
+	maxAttempts := max(c.Retry.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		entry, err := c.fetchOnce(ctx, method, reqURL, cached)
+		if err == nil {
+			return entry, nil
+		}
+		if attempt >= maxAttempts || !isRetryable(err) {
+			return nil, &RetryError{Attempts: attempt, Err: err}
+		}
+		if !sleep(ctx, c.Retry.delay(attempt, err)) {
+			return nil, &RetryError{Attempts: attempt, Err: err}
+		}
+	}
+}
+
+// fetchOnce executes a single HTTP request. If cached is not nil, the
+// request is made conditional on its validators and cached is returned,
+// refreshed, when the API answers 304 Not Modified.
+func (c *Client) fetchOnce(ctx context.Context, method, reqURL string, cached *CacheEntry) (*CacheEntry, error) {
 	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.UserAgent)
+	if cached != nil {
+		setConditionalHeaders(req, cached)
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return revalidated(cached, resp.Header), nil
+	}
+
 	if err := c.handleHTTPError(resp); err != nil {
-		return err
+		return nil, err
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if err := json.Unmarshal(body, result); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return nil
+	return &CacheEntry{
+		Body:         body,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		StoredAt:     time.Now(),
+	}, nil
 }
 
 // handleHTTPError handles HTTP error responses.
@@ -130,14 +242,16 @@ func (c *Client) handleHTTPError(resp *http.Response) error {
 			Message:    "resource not found",
 		}
 	case http.StatusTooManyRequests:
-		retryAfter := 0
-		if ra := resp.Header.Get("Retry-After"); ra != "" {
-			retryAfter, _ = strconv.Atoi(ra)
-		}
 		return &APIError{
 			StatusCode: resp.StatusCode,
 			Message:    "rate limit exceeded",
-			RetryAfter: retryAfter,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+	case http.StatusServiceUnavailable:
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    http.StatusText(resp.StatusCode),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
 		}
 	default:
 		return &APIError{
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shmokmt/endoflife-go"
+	"github.com/shmokmt/endoflife-go/scan"
+)
+
+// Exit codes of the check command.
+const (
+	exitEOL         = 2 // at least one component is end of life
+	exitWarning     = 3 // at least one component is within the warning window
+	exitLookupError = 4 // at least one component could not be looked up
+)
+
+// Check statuses, from best to worst.
+const (
+	statusOK      = "OK"
+	statusWarning = "WARN"
+	statusEOL     = "EOL"
+	statusError   = "ERROR"
+)
+
+// checkFlags are the flags shared by the check and scan commands.
+type checkFlags struct {
+	WarnDays int    `default:"90" placeholder:"DAYS" help:"Warn about components reaching end of life within DAYS days."`
+	At       string `placeholder:"YYYY-MM-DD" help:"Check the status at the given date instead of today."`
+}
+
+type checkCmd struct {
+	Components []string `arg:"" optional:"" name:"product@version" help:"Components to check, such as python@3.12.4."`
+	File       string   `short:"f" placeholder:"FILE" help:"Read components from FILE, one per line (- for stdin). Components are read from stdin if none are given."`
+
+	checkFlags `embed:""`
+}
+
+// checkResult is the result of checking one component.
+type checkResult struct {
+	Component string                    `json:"component"`
+	Source    string                    `json:"source,omitempty"`
+	Resource  string                    `json:"resource,omitempty"`
+	Container string                    `json:"container,omitempty"`
+	Product   string                    `json:"product"`
+	Version   string                    `json:"version"`
+	Release   string                    `json:"release,omitempty"`
+	Allowed   []string                  `json:"allowed,omitempty"`
+	Phase     endoflife.Phase           `json:"phase,omitempty"`
+	EOL       *endoflife.Date           `json:"eol,omitempty"`
+	Latest    string                    `json:"latest,omitempty"`
+	Patch     *endoflife.PatchStatus    `json:"-"`
+	Lifecycle endoflife.LifecycleStatus `json:"-"`
+	Status    string                    `json:"status"`
+	Error     string                    `json:"error,omitempty"`
+}
+
+// Run checks the lifecycle status of each component.
+func (cmd *checkCmd) Run(a *app) error {
+	resolver, err := cmd.newResolver(a)
+	if err != nil {
+		return err
+	}
+
+	components := cmd.Components
+	if cmd.File != "" || len(components) == 0 {
+		read, err := cmd.readComponents(a.in)
+		if err != nil {
+			return err
+		}
+		components = append(components, read...)
+	}
+	if len(components) == 0 {
+		return errors.New("no components to check")
+	}
+
+	// Malformed components are usage errors, reported before any lookup.
+	parsed := make([]scan.Component, 0, len(components))
+	for _, c := range components {
+		product, version, ok := strings.Cut(c, "@")
+		if !ok || product == "" || version == "" {
+			return fmt.Errorf("invalid component %q: expected product@version", c)
+		}
+		parsed = append(parsed, scan.Component{Product: product, Version: version})
+	}
+
+	results := make([]*checkResult, 0, len(parsed))
+	for _, c := range parsed {
+		f := resolver.Resolve(a.ctx, c)
+		results = append(results, cmd.result(f, resolver.At))
+	}
+	return printCheckResults(a, results)
+}
+
+// readComponents reads whitespace-separated components from cmd.File, or
+// from stdin. Lines starting with # are ignored.
+func (cmd *checkCmd) readComponents(stdin io.Reader) ([]string, error) {
+	r := stdin
+	if cmd.File != "" && cmd.File != "-" {
+		f, err := os.Open(cmd.File)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var components []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		components = append(components, strings.Fields(line)...)
+	}
+	return components, scanner.Err()
+}
+
+// newResolver creates a resolver evaluating statuses at the --at date, or
+// at the current time.
+func (f *checkFlags) newResolver(a *app) (*scan.Resolver, error) {
+	resolver := scan.NewResolver(a.client)
+	resolver.At = a.now()
+	if f.At != "" {
+		t, err := time.Parse("2006-01-02", f.At)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: expected YYYY-MM-DD", f.At)
+		}
+		resolver.At = t
+	}
+	return resolver, nil
+}
+
+// result converts a finding to a check result and assigns its status.
+func (f *checkFlags) result(finding scan.Finding, at time.Time) *checkResult {
+	// Errors in files being scanned may leave the version unknown.
+	component := finding.Product
+	if finding.Version != "" {
+		component += "@" + finding.Version
+	}
+	result := &checkResult{
+		Component: component,
+		Resource:  finding.Resource,
+		Container: finding.Container,
+		Product:   finding.Product,
+		Version:   finding.Version,
+	}
+	if finding.Path != "" {
+		result.Source = finding.Path
+		if finding.Line > 0 {
+			result.Source += ":" + strconv.Itoa(finding.Line)
+		}
+	}
+	if finding.Err != nil {
+		result.Status = statusError
+		result.Error = finding.Err.Error()
+		return result
+	}
+
+	release := finding.Release
+	result.Release = release.Name
+	for _, r := range finding.Allowed {
+		result.Allowed = append(result.Allowed, r.Name)
+	}
+	if len(finding.Allowed) > 1 {
+		result.Release = release.Name + " - " + finding.Allowed[0].Name
+	}
+	result.Lifecycle = finding.Lifecycle
+	result.Phase = finding.Lifecycle.Phase
+	result.EOL = release.EOLFrom
+	result.Patch = finding.Patch
+	if release.Latest != nil {
+		result.Latest = release.Latest.Name
+	}
+
+	// A range allowing an end of life release is as bad as pinning one.
+	switch {
+	case result.Phase == endoflife.PhaseEOL || finding.AllowsEOL:
+		result.Status = statusEOL
+	case result.Phase == endoflife.PhaseExtended:
+		result.Status = statusWarning
+	case release.EOLFrom != nil && !release.EOLFrom.IsZero() &&
+		release.EOLFrom.Sub(at) <= time.Duration(f.WarnDays)*24*time.Hour:
+		result.Status = statusWarning
+	default:
+		result.Status = statusOK
+	}
+	return result
+}
+
+// printCheckResults writes the results as a table or as JSON, and returns
+// an exitError for the worst result.
+func printCheckResults(a *app, results []*checkResult) error {
+	if err := writeCheckResults(a, results); err != nil {
+		return err
+	}
+	return checkExitCode(results)
+}
+
+func writeCheckResults(a *app, results []*checkResult) error {
+	if a.json {
+		return printJSON(a.out, results)
+	}
+
+	// Results of scans have a source column, and those of Kubernetes
+	// manifests resource and container columns.
+	withSource := slices.ContainsFunc(results, func(r *checkResult) bool { return r.Source != "" })
+	withResource := slices.ContainsFunc(results, func(r *checkResult) bool { return r.Resource != "" })
+
+	tw := newTabWriter(a.out)
+	if withSource {
+		fmt.Fprint(tw, "SOURCE\t")
+	}
+	if withResource {
+		fmt.Fprint(tw, "RESOURCE\tCONTAINER\t")
+	}
+	fmt.Fprintln(tw, "COMPONENT\tRELEASE\tPHASE\tEOL\tLATEST\tSTATUS")
+	for _, r := range results {
+		if withSource {
+			fmt.Fprintf(tw, "%s\t", r.Source)
+		}
+		if withResource {
+			fmt.Fprintf(tw, "%s\t%s\t", orDash(r.Resource), orDash(r.Container))
+		}
+		if r.Error != "" {
+			fmt.Fprintf(tw, "%s\t-\t-\t-\t-\t%s: %s\n", r.Component, r.Status, r.Error)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Component, r.Release, r.Phase, formatDate(r.EOL, false), formatLatest(r), r.Status)
+	}
+	return tw.Flush()
+}
+
+// formatLatest returns the latest version, noting how far behind the
+// checked version is.
+func formatLatest(r *checkResult) string {
+	switch {
+	case r.Latest == "":
+		return "-"
+	case r.Patch == nil || r.Patch.UpToDate:
+		return r.Latest
+	default:
+		return fmt.Sprintf("%s (%d behind)", r.Latest, r.Patch.PatchesBehind)
+	}
+}
+
+// checkExitCode returns an exitError for the worst result, or nil if all
+// components are OK. End of life takes precedence over lookup errors, which
+// take precedence over warnings.
+func checkExitCode(results []*checkResult) error {
+	seen := make(map[string]bool)
+	for _, r := range results {
+		seen[r.Status] = true
+	}
+	switch {
+	case seen[statusEOL]:
+		return &exitError{code: exitEOL}
+	case seen[statusError]:
+		return &exitError{code: exitLookupError}
+	case seen[statusWarning]:
+		return &exitError{code: exitWarning}
+	default:
+		return nil
+	}
+}
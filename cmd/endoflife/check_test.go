@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shmokmt/endoflife-go/endoflifetest"
+)
+
+func TestCheckCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+		contains []string
+	}{
+		{
+			name:     "ok",
+			args:     []string{"python@3.12.4", "nodejs@22.12.0"},
+			expected: 0,
+			contains: []string{"python@3.12.4", "3.12.8 (4 behind)", "active", "OK"},
+		},
+		{
+			name:     "warning",
+			args:     []string{"python@3.12.4", "nodejs@18.20.0", "--warn-days", "120"},
+			expected: exitWarning,
+			contains: []string{"2025-04-30", "WARN"},
+		},
+		{
+			name:     "eol",
+			args:     []string{"python@3.8.10", "nodejs@18.20.0", "nonexistent@1.0"},
+			expected: exitEOL,
+			contains: []string{"eol", "EOL"},
+		},
+		{
+			name:     "lookup error",
+			args:     []string{"python@3.12.4", "nonexistent@1.0", "python@4.0"},
+			expected: exitLookupError,
+			contains: []string{"ERROR: API error: 404", "no matching release"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"check", "--at", "2025-01-15"}, tt.args...)
+			code, stdout, stderr := runCLI(t, server.URL, args...)
+			if code != tt.expected {
+				t.Errorf("expected exit code %d, got %d (stderr: %s)", tt.expected, code, stderr)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, stdout)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckCmd_Input(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	input := "# runtimes\npython@3.12.4 nodejs@22.12.0\n\nubuntu@22.04\n"
+
+	code, stdout, stderr := runCLIWithInput(t, server.URL, input, "check", "--at", "2025-01-15", "--json")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	var results []checkResult
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("failed to decode output: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[2].Release != "22.04" || results[2].Status != statusOK {
+		t.Errorf("unexpected result: %+v", results[2])
+	}
+
+	path := filepath.Join(t.TempDir(), "components.txt")
+	if err := os.WriteFile(path, []byte("python@3.8\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, _, _ = runCLI(t, server.URL, "check", "--at", "2025-01-15", "-f", path)
+	if code != exitEOL {
+		t.Errorf("expected exit code %d, got %d", exitEOL, code)
+	}
+}
+
+func TestCheckCmd_InvalidComponent(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	for _, args := range [][]string{{"python"}, {"python@3.8.10", "nodejs@"}} {
+		code, stdout, stderr := runCLI(t, server.URL, append([]string{"check", "--at", "2025-01-15"}, args...)...)
+		if code != 1 {
+			t.Errorf("%v: expected exit code 1, got %d", args, code)
+		}
+		if !strings.Contains(stderr, "expected product@version") || stdout != "" {
+			t.Errorf("%v: unexpected output: %s%s", args, stdout, stderr)
+		}
+	}
+	if n := server.Requests("/products/python"); n != 0 {
+		t.Errorf("expected no lookups before validation, got %d", n)
+	}
+}
+
+func TestCheckCmd_InvalidDate(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	code, _, stderr := runCLI(t, server.URL, "check", "--at", "tomorrow", "python@3.12")
+	if code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr, "invalid date") {
+		t.Errorf("unexpected error: %s", stderr)
+	}
+}
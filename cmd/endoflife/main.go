@@ -0,0 +1,216 @@
+// Command endoflife is a command-line client for the endoflife.date API.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"github.com/shmokmt/endoflife-go"
+)
+
+// cli is the command-line grammar.
+type cli struct {
+	JSON     bool          `help:"Output in JSON format."`
+	Timeout  time.Duration `default:"30s" help:"HTTP timeout."`
+	BaseURL  string        `name:"base-url" hidden:"" default:"${base_url}" help:"Base URL of the API."`
+	CacheTTL time.Duration `env:"ENDOFLIFE_CACHE_TTL" help:"Reuse responses cached on disk for this long (0 disables the cache)."`
+	CacheDir string        `env:"ENDOFLIFE_CACHE_DIR" type:"path" placeholder:"DIR" help:"Cache directory (default: user cache directory)."`
+	Offline  string        `env:"ENDOFLIFE_SNAPSHOT" type:"existingfile" placeholder:"FILE" help:"Answer from a snapshot file instead of the API."`
+
+	Products productsCmd `cmd:"" help:"List all products."`
+	Product  productCmd  `cmd:"" help:"Get product details."`
+	Check    checkCmd    `cmd:"" help:"Check components for end of life. Exits with 2 if any is end of life, 3 if any is within the warning window, and 4 if any could not be looked up."`
+	Scan     scanCmd     `cmd:"" help:"Scan project files for components and check them like the check command."`
+	Snapshot snapshotCmd `cmd:"" help:"Export all product data for offline use."`
+	Version  versionCmd  `cmd:"" help:"Show version."`
+}
+
+// app holds the state shared by all commands.
+type app struct {
+	client endoflife.API
+	ctx    context.Context
+	in     io.Reader
+	out    io.Writer
+	json   bool
+	now    func() time.Time
+}
+
+// exitError makes run exit with the given code without printing an error.
+type exitError struct {
+	code int
+}
+
+// Error implements the error interface.
+func (e *exitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run parses args, executes the selected command and returns the exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var c cli
+	parser, err := kong.New(&c,
+		kong.Name("endoflife"),
+		kong.Description("Query end-of-life information from endoflife.date."),
+		kong.Writers(stdout, stderr),
+		kong.Vars{"base_url": endoflife.DefaultBaseURL},
+	)
+	if err != nil {
+		fmt.Fprintf(stderr, "endoflife: error: %v\n", err)
+		return 1
+	}
+
+	kctx, err := parser.Parse(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "endoflife: error: %v\n", err)
+		return 1
+	}
+
+	client, err := c.newClient()
+	if err != nil {
+		fmt.Fprintf(stderr, "endoflife: error: %v\n", err)
+		return 1
+	}
+
+	a := &app{
+		client: client,
+		ctx:    ctx,
+		in:     stdin,
+		out:    stdout,
+		json:   c.JSON,
+		now:    time.Now,
+	}
+	if err := kctx.Run(a); err != nil {
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			return exitErr.code
+		}
+		fmt.Fprintf(stderr, "endoflife: error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// newClient creates the API client configured by the global flags.
+func (c *cli) newClient() (endoflife.API, error) {
+	if c.Offline != "" {
+		return endoflife.LoadSnapshot(c.Offline)
+	}
+
+	opts := []endoflife.Option{
+		endoflife.WithBaseURL(c.BaseURL),
+		endoflife.WithHTTPClient(&http.Client{Timeout: c.Timeout}),
+	}
+	if c.CacheTTL > 0 {
+		dir := c.CacheDir
+		if dir == "" {
+			var err error
+			if dir, err = endoflife.DefaultCacheDir(); err != nil {
+				return nil, fmt.Errorf("failed to locate cache directory: %w", err)
+			}
+		}
+		opts = append(opts, endoflife.WithCache(endoflife.NewFileCache(dir), c.CacheTTL))
+	}
+	return endoflife.NewClientWithOptions(opts...), nil
+}
+
+type productsCmd struct{}
+
+// Run lists all products.
+func (cmd *productsCmd) Run(a *app) error {
+	products, err := a.client.GetProducts(a.ctx)
+	if err != nil {
+		return err
+	}
+	if a.json {
+		return printJSON(a.out, products)
+	}
+	return printProducts(a.out, products)
+}
+
+type productCmd struct {
+	Name    string `arg:"" help:"Product name."`
+	Release string `placeholder:"VERSION" xor:"release" help:"Get specific release info."`
+	Latest  bool   `xor:"release" help:"Get latest release info."`
+}
+
+// Run shows a product, or one of its releases.
+func (cmd *productCmd) Run(a *app) error {
+	if cmd.Release != "" || cmd.Latest {
+		var (
+			release *endoflife.ProductReleaseResponse
+			err     error
+		)
+		if cmd.Latest {
+			release, err = a.client.GetLatestRelease(a.ctx, cmd.Name)
+		} else {
+			release, err = a.client.GetRelease(a.ctx, cmd.Name, cmd.Release)
+		}
+		if err != nil {
+			return err
+		}
+		if a.json {
+			return printJSON(a.out, release)
+		}
+		return printRelease(a.out, &release.Result)
+	}
+
+	product, err := a.client.GetProduct(a.ctx, cmd.Name)
+	if err != nil {
+		return err
+	}
+	if a.json {
+		return printJSON(a.out, product)
+	}
+	return printProduct(a.out, &product.Result)
+}
+
+type snapshotCmd struct {
+	Output string `short:"o" type:"path" placeholder:"FILE" help:"Write the snapshot to FILE instead of stdout."`
+}
+
+// Run exports a snapshot of all products.
+func (cmd *snapshotCmd) Run(a *app) error {
+	full, err := a.client.GetProductsFull(a.ctx)
+	if err != nil {
+		return err
+	}
+	snapshot := endoflife.NewSnapshot(full)
+	if cmd.Output == "" {
+		return snapshot.Write(a.out)
+	}
+	return writeFile(cmd.Output, snapshot.Write)
+}
+
+// writeFile creates a file and writes to it with write.
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type versionCmd struct{}
+
+// Run prints the version.
+func (cmd *versionCmd) Run(a *app) error {
+	if a.json {
+		return printJSON(a.out, map[string]string{"version": endoflife.Version})
+	}
+	_, err := fmt.Fprintf(a.out, "endoflife version %s\n", endoflife.Version)
+	return err
+}
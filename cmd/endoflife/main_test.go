@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shmokmt/endoflife-go"
+)
+
+func setupTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func runCLI(t *testing.T, baseURL string, args ...string) (int, string, string) {
+	t.Helper()
+	return runCLIWithInput(t, baseURL, "", args...)
+}
+
+func runCLIWithInput(t *testing.T, baseURL string, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"--base-url", baseURL}, args...)
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestProductsCmd(t *testing.T) {
+	server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/products" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(endoflife.ProductListResponse{
+			Total: 1,
+			Result: []endoflife.ProductSummary{
+				{Name: "python", Label: "Python", Category: "lang"},
+			},
+		})
+	})
+
+	code, stdout, stderr := runCLI(t, server.URL, "products")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stdout, "NAME") || !strings.Contains(stdout, "python") {
+		t.Errorf("unexpected output: %s", stdout)
+	}
+}
+
+func TestProductCmd(t *testing.T) {
+	eol := endoflife.Date{Time: time.Date(2028, 10, 31, 0, 0, 0, 0, time.UTC)}
+	server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(endoflife.ProductResponse{
+			Result: endoflife.ProductDetails{
+				Name:     "python",
+				Label:    "Python",
+				Category: "lang",
+				Releases: []endoflife.ProductRelease{
+					{Name: "3.12", EOLFrom: &eol, IsMaintained: true, Latest: &endoflife.ProductVersion{Name: "3.12.4"}},
+				},
+			},
+		})
+	})
+
+	code, stdout, stderr := runCLI(t, server.URL, "product", "python")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	for _, want := range []string{"Python (python)", "3.12", "2028-10-31", "3.12.4"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected output to contain %q, got %s", want, stdout)
+		}
+	}
+}
+
+func TestProductCmd_Release(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		path string
+	}{
+		{name: "release", args: []string{"product", "python", "--release", "3.12"}, path: "/products/python/releases/3.12"},
+		{name: "latest", args: []string{"product", "python", "--latest"}, path: "/products/python/releases/latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.path {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				json.NewEncoder(w).Encode(endoflife.ProductReleaseResponse{
+					SchemaVersion: "1.2.0",
+					Result:        endoflife.ProductRelease{Name: "3.12", IsMaintained: true},
+				})
+			})
+
+			code, stdout, stderr := runCLI(t, server.URL, append(tt.args, "--json")...)
+			if code != 0 {
+				t.Fatalf("expected exit code 0, got %d (stderr: %s)", code, stderr)
+			}
+			var result endoflife.ProductReleaseResponse
+			if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+				t.Fatalf("failed to decode output: %v", err)
+			}
+			if result.Result.Name != "3.12" {
+				t.Errorf("expected release 3.12, got %s", result.Result.Name)
+			}
+		})
+	}
+}
+
+func TestProductCmd_ReleaseAndLatest(t *testing.T) {
+	server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s", r.URL.Path)
+	})
+
+	code, _, stderr := runCLI(t, server.URL, "product", "python", "--release", "3.12", "--latest")
+	if code == 0 {
+		t.Fatal("expected non-zero exit code")
+	}
+	if stderr == "" {
+		t.Error("expected error message")
+	}
+}
+
+func TestProductCmd_NotFound(t *testing.T) {
+	server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	code, _, stderr := runCLI(t, server.URL, "product", "nonexistent")
+	if code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr, "404") {
+		t.Errorf("expected 404 in error message, got %s", stderr)
+	}
+}
+
+func TestVersionCmd(t *testing.T) {
+	server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	code, stdout, _ := runCLI(t, server.URL, "version")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if strings.TrimSpace(stdout) != "endoflife version "+endoflife.Version {
+		t.Errorf("unexpected output: %s", stdout)
+	}
+}
+
+func TestCache(t *testing.T) {
+	calls := 0
+	server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		json.NewEncoder(w).Encode(endoflife.ProductListResponse{Total: 0})
+	})
+
+	dir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		code, _, stderr := runCLI(t, server.URL, "--cache-ttl", "1h", "--cache-dir", dir, "products")
+		if code != 0 {
+			t.Fatalf("expected exit code 0, got %d (stderr: %s)", code, stderr)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestSnapshotCmd(t *testing.T) {
+	server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/products/full" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(endoflife.FullProductListResponse{
+			Total:  1,
+			Result: []endoflife.ProductDetails{{Name: "python"}},
+		})
+	})
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	code, _, stderr := runCLI(t, server.URL, "snapshot", "-o", path)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+
+	snapshot, err := endoflife.LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := snapshot.GetProduct(context.Background(), "python"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestOffline(t *testing.T) {
+	server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s", r.URL.Path)
+	})
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	data, _ := json.Marshal(endoflife.FullProductListResponse{
+		Total: 1,
+		Result: []endoflife.ProductDetails{{
+			Name:     "python",
+			Label:    "Python",
+			Releases: []endoflife.ProductRelease{{Name: "3.12"}},
+		}},
+	})
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "--offline", path, "product", "python", "--latest")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stdout, "3.12") {
+		t.Errorf("unexpected output: %s", stdout)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/shmokmt/endoflife-go"
+)
+
+// printJSON writes v as indented JSON.
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printProducts writes a table of product summaries.
+func printProducts(w io.Writer, products *endoflife.ProductListResponse) error {
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "NAME\tLABEL\tCATEGORY")
+	for _, p := range products.Result {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", p.Name, p.Label, p.Category)
+	}
+	return tw.Flush()
+}
+
+// printProduct writes product details followed by a table of its releases.
+func printProduct(w io.Writer, p *endoflife.ProductDetails) error {
+	fmt.Fprintf(w, "%s (%s)\n", p.Label, p.Name)
+	fmt.Fprintf(w, "Category: %s\n", p.Category)
+	if p.Links.HTML != "" {
+		fmt.Fprintf(w, "Link:     %s\n", p.Links.HTML)
+	}
+	fmt.Fprintln(w)
+
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "RELEASE\tRELEASED\tEOL\tLATEST\tMAINTAINED")
+	for _, r := range p.Releases {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			r.Name, dateOrDash(r.ReleaseDate), formatDate(r.EOLFrom, r.IsEOL),
+			latestName(r.Latest), yesNo(r.IsMaintained))
+	}
+	return tw.Flush()
+}
+
+// printRelease writes the details of a single release.
+func printRelease(w io.Writer, r *endoflife.ProductRelease) error {
+	tw := newTabWriter(w)
+	fmt.Fprintf(tw, "Release:\t%s\n", r.Name)
+	if r.Codename != nil {
+		fmt.Fprintf(tw, "Codename:\t%s\n", *r.Codename)
+	}
+	fmt.Fprintf(tw, "Released:\t%s\n", dateOrDash(r.ReleaseDate))
+	fmt.Fprintf(tw, "LTS:\t%s\n", formatDate(r.LTSFrom, r.IsLTS))
+	if r.EOASFrom != nil || r.IsEOAS {
+		fmt.Fprintf(tw, "EOAS:\t%s\n", formatDate(r.EOASFrom, r.IsEOAS))
+	}
+	fmt.Fprintf(tw, "EOL:\t%s\n", formatDate(r.EOLFrom, r.IsEOL))
+	if r.EOESFrom != nil || r.IsEOES != nil {
+		fmt.Fprintf(tw, "EOES:\t%s\n", formatDate(r.EOESFrom, r.IsEOES != nil && *r.IsEOES))
+	}
+	if r.DiscontinuedFrom != nil || r.IsDiscontinued {
+		fmt.Fprintf(tw, "Discontinued:\t%s\n", formatDate(r.DiscontinuedFrom, r.IsDiscontinued))
+	}
+	fmt.Fprintf(tw, "Maintained:\t%s\n", yesNo(r.IsMaintained))
+	if r.Latest != nil {
+		latest := r.Latest.Name
+		if r.Latest.Date != nil {
+			latest += " (" + r.Latest.Date.String() + ")"
+		}
+		fmt.Fprintf(tw, "Latest:\t%s\n", latest)
+		if r.Latest.Link != nil {
+			fmt.Fprintf(tw, "Link:\t%s\n", *r.Latest.Link)
+		}
+	}
+	return tw.Flush()
+}
+
+func newTabWriter(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+}
+
+// formatDate returns the date if known, otherwise the boolean flag.
+func formatDate(d *endoflife.Date, flag bool) string {
+	if d != nil && !d.IsZero() {
+		return d.String()
+	}
+	return yesNo(flag)
+}
+
+func dateOrDash(d endoflife.Date) string {
+	if d.IsZero() {
+		return "-"
+	}
+	return d.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func latestName(v *endoflife.ProductVersion) string {
+	if v == nil {
+		return "-"
+	}
+	return v.Name
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/shmokmt/endoflife-go/scan"
+)
+
+type scanCmd struct {
+	GoMod      scanGoModCmd      `cmd:"" name:"gomod" help:"Check the Go versions required by go.mod files."`
+	Dockerfile scanDockerfileCmd `cmd:"" help:"Check the base images of Dockerfiles."`
+	Node       scanNodeCmd       `cmd:"" help:"Check the Node.js versions of package.json engines and volta fields, .nvmrc and .node-version files."`
+	Python     scanPythonCmd     `cmd:"" help:"Check the Python versions and framework pins of Python projects."`
+	Tools      scanToolsCmd      `cmd:"" help:"Check the versions pinned in asdf .tool-versions and mise.toml files."`
+	Kubernetes scanKubernetesCmd `cmd:"" aliases:"k8s" help:"Check the container images of Kubernetes manifests and rendered Helm charts."`
+	Kubectl    scanKubectlCmd    `cmd:"" help:"Check the cluster version and node software in the JSON output of kubectl version and kubectl get nodes."`
+	Terraform  scanTerraformCmd  `cmd:"" aliases:"tf" help:"Check the Terraform and managed service versions of Terraform configurations. Provider versions are ignored unless mapped with --provider."`
+	Lambda     scanLambdaCmd     `cmd:"" aliases:"serverless" help:"Check the Lambda runtimes of SAM, CloudFormation and Serverless Framework templates and aws lambda list-functions output."`
+	OSRelease  scanOSReleaseCmd  `cmd:"" name:"os-release" help:"Check the operating systems of os-release files, such as those collected from a fleet of hosts."`
+	Host       scanHostCmd       `cmd:"" help:"Check the operating system of the local host from its os-release file."`
+	SBOM       scanSBOMCmd       `cmd:"" name:"sbom" help:"Check the components of a CycloneDX or SPDX SBOM, optionally writing it back annotated with end-of-life data."`
+}
+
+// scanFunc finds and resolves components under root.
+type scanFunc func(ctx context.Context, r *scan.Resolver, root string) ([]scan.Finding, error)
+
+// runScan runs a scanner and reports its findings like the check command.
+func runScan(a *app, flags *checkFlags, root string, fn scanFunc) error {
+	resolver, err := flags.newResolver(a)
+	if err != nil {
+		return err
+	}
+	findings, err := fn(a.ctx, resolver, root)
+	if err != nil {
+		return err
+	}
+
+	results := make([]*checkResult, 0, len(findings))
+	for _, f := range findings {
+		results = append(results, flags.result(f, resolver.At))
+	}
+	return printCheckResults(a, results)
+}
+
+type scanGoModCmd struct {
+	Path string `arg:"" optional:"" default:"." type:"path" help:"Directory to scan, or a single go.mod file."`
+
+	checkFlags `embed:""`
+}
+
+// Run scans go.mod files for the go and toolchain directives.
+func (cmd *scanGoModCmd) Run(a *app) error {
+	return runScan(a, &cmd.checkFlags, cmd.Path, scan.ScanGoMod)
+}
+
+type scanDockerfileCmd struct {
+	Path      string            `arg:"" optional:"" default:"." type:"path" help:"Directory to scan, or a single Dockerfile."`
+	BuildArgs map[string]string `name:"build-arg" placeholder:"KEY=VALUE" help:"Set a build-time variable used in FROM instructions."`
+
+	checkFlags `embed:""`
+}
+
+// Run scans Dockerfiles for the images of FROM instructions.
+func (cmd *scanDockerfileCmd) Run(a *app) error {
+	return runScan(a, &cmd.checkFlags, cmd.Path, func(ctx context.Context, r *scan.Resolver, root string) ([]scan.Finding, error) {
+		return scan.ScanDockerfiles(ctx, r, root, cmd.BuildArgs)
+	})
+}
+
+type scanNodeCmd struct {
+	Path string `arg:"" optional:"" default:"." type:"path" help:"Directory to scan, or a single file."`
+
+	checkFlags `embed:""`
+}
+
+// Run scans Node.js project manifests and version manager files.
+func (cmd *scanNodeCmd) Run(a *app) error {
+	return runScan(a, &cmd.checkFlags, cmd.Path, scan.ScanNode)
+}
+
+type scanPythonCmd struct {
+	Path string `arg:"" optional:"" default:"." type:"path" help:"Directory to scan, or a single file."`
+
+	checkFlags `embed:""`
+}
+
+// Run scans Python project files.
+func (cmd *scanPythonCmd) Run(a *app) error {
+	return runScan(a, &cmd.checkFlags, cmd.Path, scan.ScanPython)
+}
+
+type scanToolsCmd struct {
+	Path string `arg:"" optional:"" default:"." type:"path" help:"Directory to scan, or a single file."`
+
+	checkFlags `embed:""`
+}
+
+// Run scans version manager files.
+func (cmd *scanToolsCmd) Run(a *app) error {
+	return runScan(a, &cmd.checkFlags, cmd.Path, scan.ScanToolVersions)
+}
+
+type scanKubernetesCmd struct {
+	Path string `arg:"" optional:"" default:"." type:"path" help:"Directory to scan, or a single manifest."`
+
+	checkFlags `embed:""`
+}
+
+// Run scans Kubernetes manifests for the images of containers.
+func (cmd *scanKubernetesCmd) Run(a *app) error {
+	return runScan(a, &cmd.checkFlags, cmd.Path, scan.ScanKubernetes)
+}
+
+type scanKubectlCmd struct {
+	Path string `arg:"" optional:"" default:"." type:"path" help:"Directory to scan, or a single JSON file."`
+
+	checkFlags `embed:""`
+}
+
+// Run scans the output of kubectl for cluster and node versions.
+func (cmd *scanKubectlCmd) Run(a *app) error {
+	return runScan(a, &cmd.checkFlags, cmd.Path, scan.ScanKubectl)
+}
+
+type scanTerraformCmd struct {
+	Path      string            `arg:"" optional:"" default:"." type:"path" help:"Directory to scan, or a single file."`
+	Providers map[string]string `name:"provider" placeholder:"SOURCE=PRODUCT" help:"Check the versions of a provider, such as hashicorp/aws, against a product. Providers that are not mapped are ignored."`
+
+	checkFlags `embed:""`
+}
+
+// Run scans Terraform configurations and dependency lock files.
+func (cmd *scanTerraformCmd) Run(a *app) error {
+	return runScan(a, &cmd.checkFlags, cmd.Path, func(ctx context.Context, r *scan.Resolver, root string) ([]scan.Finding, error) {
+		return scan.ScanTerraform(ctx, r, root, cmd.Providers)
+	})
+}
+
+type scanLambdaCmd struct {
+	Path string `arg:"" optional:"" default:"." type:"path" help:"Directory to scan, or a single file."`
+
+	checkFlags `embed:""`
+}
+
+// Run scans templates and AWS CLI output for Lambda runtimes.
+func (cmd *scanLambdaCmd) Run(a *app) error {
+	return runScan(a, &cmd.checkFlags, cmd.Path, scan.ScanLambda)
+}
+
+type scanOSReleaseCmd struct {
+	Path string `arg:"" optional:"" default:"." type:"path" help:"Directory to scan, or a single os-release file."`
+
+	checkFlags `embed:""`
+}
+
+// Run scans os-release files for operating system versions.
+func (cmd *scanOSReleaseCmd) Run(a *app) error {
+	return runScan(a, &cmd.checkFlags, cmd.Path, scan.ScanOSRelease)
+}
+
+type scanHostCmd struct {
+	Root string `arg:"" optional:"" default:"/" type:"path" help:"Root of the file system of the host, such as a mounted disk image."`
+
+	checkFlags `embed:""`
+}
+
+// Run checks the operating system of the host.
+func (cmd *scanHostCmd) Run(a *app) error {
+	return runScan(a, &cmd.checkFlags, cmd.Root, scan.ScanHost)
+}
+
+type scanSBOMCmd struct {
+	File   string `arg:"" type:"existingfile" help:"CycloneDX JSON, SPDX JSON or SPDX tag-value document."`
+	Output string `short:"o" placeholder:"FILE" help:"Write the SBOM annotated with end-of-life data to FILE (- for stdout, instead of the results)."`
+
+	checkFlags `embed:""`
+}
+
+// Run matches the components of an SBOM with products and annotates them.
+func (cmd *scanSBOMCmd) Run(a *app) error {
+	resolver, err := cmd.newResolver(a)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(cmd.File)
+	if err != nil {
+		return err
+	}
+	doc, err := scan.ReadSBOM(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	findings, err := doc.Annotate(a.ctx, resolver)
+	if err != nil {
+		return err
+	}
+
+	results := make([]*checkResult, 0, len(findings))
+	for _, finding := range findings {
+		finding.Path = cmd.File
+		results = append(results, cmd.result(finding, resolver.At))
+	}
+
+	switch cmd.Output {
+	case "":
+		return printCheckResults(a, results)
+	case "-":
+		if err := doc.Write(a.out); err != nil {
+			return err
+		}
+		return checkExitCode(results)
+	default:
+		if err := writeFile(cmd.Output, doc.Write); err != nil {
+			return err
+		}
+		return printCheckResults(a, results)
+	}
+}
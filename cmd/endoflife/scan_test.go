@@ -0,0 +1,353 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shmokmt/endoflife-go/endoflifetest"
+)
+
+func TestScanGoModCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.21.5\n\ntoolchain go1.23.10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "gomod", dir, "--at", "2025-01-15")
+	if code != exitEOL {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitEOL, code, stderr)
+	}
+	for _, want := range []string{
+		"SOURCE",
+		filepath.Join(dir, "go.mod") + ":3",
+		"go@1.21.5",
+		"1.21.13 (8 behind)",
+		"EOL",
+		filepath.Join(dir, "go.mod") + ":5",
+		"go@1.23.10",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+
+	code, _, stderr = runCLI(t, server.URL, "scan", "gomod", filepath.Join(dir, "missing"))
+	if code != 1 || !strings.Contains(stderr, "no such file") {
+		t.Errorf("expected error for missing path, got %d: %s", code, stderr)
+	}
+}
+
+func TestScanDockerfileCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "Dockerfile")
+	if err := os.WriteFile(path, []byte("ARG PYTHON=3.12\nFROM python:${PYTHON}-alpine3.20\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "dockerfile", path, "--at", "2025-01-15")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	for _, want := range []string{path + ":2", "python@3.12", "alpine@3.20"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+
+	code, stdout, _ = runCLI(t, server.URL, "scan", "dockerfile", path, "--at", "2025-01-15", "--build-arg", "PYTHON=3.8")
+	if code != exitEOL || !strings.Contains(stdout, "python@3.8") {
+		t.Errorf("expected python 3.8 to be end of life, got %d:\n%s", code, stdout)
+	}
+}
+
+func TestScanNodeCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"engines": {"node": ">=14"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "node", dir, "--at", "2025-01-15")
+	if code != exitEOL {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitEOL, code, stderr)
+	}
+	for _, want := range []string{"nodejs@>=14", "14 - 23", "EOL"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+
+	code, stdout, _ = runCLI(t, server.URL, "--json", "scan", "node", dir, "--at", "2025-01-15")
+	if code != exitEOL || !strings.Contains(stdout, `"allowed": [`) {
+		t.Errorf("expected allowed releases in JSON output, got %d:\n%s", code, stdout)
+	}
+	broken := filepath.Join(dir, "broken")
+	if err := os.Mkdir(broken, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(broken, "package.json"), []byte(`{"engines": {"node": "lts"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, stderr = runCLI(t, server.URL, "scan", "node", dir, "--at", "2025-01-15")
+	if code != exitEOL {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitEOL, code, stderr)
+	}
+	for _, want := range []string{"nodejs@>=14", "nodejs@lts", filepath.Join(broken, "package.json") + ":1"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestScanPythonCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"pyproject.toml":   "[project]\nrequires-python = \">=3.11\"\n",
+		"requirements.txt": "Django==4.2.7\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "python", dir, "--at", "2025-01-15")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	for _, want := range []string{"python@>=3.11", "3.11 - 3.13", "django@4.2.7", "4.2.17 (10 behind)"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestScanToolsCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), ".tool-versions")
+	if err := os.WriteFile(path, []byte("golang 1.24.4\nnodejs 20.18.1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "tools", path, "--at", "2025-01-15")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	for _, want := range []string{path + ":1", "go@1.24.4", path + ":2", "nodejs@20.18.1"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestScanKubernetesCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "deployment.yaml")
+	manifest := `kind: Deployment
+metadata:
+  name: web
+  namespace: prod
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          image: python:3.8-slim
+`
+	if err := os.WriteFile(path, []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "k8s", path, "--at", "2025-01-15")
+	if code != exitEOL {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitEOL, code, stderr)
+	}
+	for _, want := range []string{"RESOURCE", "CONTAINER", path + ":10", "prod/Deployment/web", "app", "python@3.8"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+
+	_, stdout, _ = runCLI(t, server.URL, "--json", "scan", "kubernetes", path, "--at", "2025-01-15")
+	if !strings.Contains(stdout, `"resource": "prod/Deployment/web"`) || !strings.Contains(stdout, `"container": "app"`) {
+		t.Errorf("expected resource and container in JSON output, got:\n%s", stdout)
+	}
+
+	broken := filepath.Join(filepath.Dir(path), "broken.yaml")
+	if err := os.WriteFile(broken, []byte("kind: Pod\nspec: [\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, stderr = runCLI(t, server.URL, "scan", "k8s", broken, "--at", "2025-01-15")
+	if code != exitLookupError {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitLookupError, code, stderr)
+	}
+	if !strings.Contains(stdout, broken+":2") {
+		t.Errorf("expected output to contain %q, got:\n%s", broken+":2", stdout)
+	}
+}
+
+func TestScanKubectlCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "nodes.json")
+	nodes := `{"kind": "NodeList", "items": [{"kind": "Node", "metadata": {"name": "node-1"},
+		"status": {"nodeInfo": {"osImage": "Ubuntu 20.04.6 LTS", "kubeletVersion": "v1.28.15"}}}]}`
+	if err := os.WriteFile(path, []byte(nodes), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "kubectl", path, "--at", "2025-01-15")
+	if code != exitEOL {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitEOL, code, stderr)
+	}
+	for _, want := range []string{"Node/node-1", "ubuntu@20.04.6", "kubernetes@1.28.15"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestScanTerraformCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	dir := t.TempDir()
+	lock := "provider \"registry.terraform.io/hashicorp/kubernetes\" {\n  version = \"1.28.3\"\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, ".terraform.lock.hcl"), []byte(lock), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	versions := "terraform {\n  required_version = \"~> 1.9\"\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "versions.tf"), []byte(versions), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "terraform", dir, "--provider", "hashicorp/kubernetes=kubernetes", "--at", "2025-01-15")
+	if code != exitEOL {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitEOL, code, stderr)
+	}
+	for _, want := range []string{"kubernetes@1.28.3", "terraform@~> 1.9"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestScanLambdaCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "serverless.yml")
+	config := "service: orders\nprovider:\n  name: aws\n  runtime: python3.8\n"
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "lambda", path, "--at", "2025-01-15")
+	if code != exitEOL {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitEOL, code, stderr)
+	}
+	if !strings.Contains(stdout, "python@3.8") {
+		t.Errorf("expected output to contain python@3.8, got:\n%s", stdout)
+	}
+}
+
+func TestScanHostCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "etc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	osRelease := "PRETTY_NAME=\"Debian GNU/Linux 10 (buster)\"\nID=debian\nVERSION_ID=\"10\"\n"
+	if err := os.WriteFile(filepath.Join(root, "etc", "os-release"), []byte(osRelease), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "host", root, "--at", "2025-01-15")
+	if code != exitEOL {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitEOL, code, stderr)
+	}
+	if !strings.Contains(stdout, "debian@10") {
+		t.Errorf("expected output to contain debian@10, got:\n%s", stdout)
+	}
+}
+
+func TestScanSBOMCmd(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bom.json")
+	bom := `{"bomFormat": "CycloneDX", "specVersion": "1.5", "components": [
+		{"type": "library", "name": "django", "version": "3.2.25", "purl": "pkg:pypi/django@3.2.25"},
+		{"type": "library", "name": "left-pad", "version": "1.3.0", "purl": "pkg:npm/left-pad@1.3.0"}
+	]}`
+	if err := os.WriteFile(path, []byte(bom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	output := filepath.Join(dir, "annotated.json")
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "sbom", path, "-o", output, "--at", "2025-01-15")
+	if code != exitEOL {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitEOL, code, stderr)
+	}
+	if !strings.Contains(stdout, "django@3.2.25") || strings.Contains(stdout, "left-pad") {
+		t.Errorf("unexpected output:\n%s", stdout)
+	}
+	annotated, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(annotated), `"value": "2024-04-01"`) {
+		t.Errorf("expected annotated SBOM, got:\n%s", annotated)
+	}
+
+	code, stdout, _ = runCLI(t, server.URL, "scan", "sbom", path, "-o", "-", "--at", "2025-01-15")
+	if code != exitEOL || !strings.Contains(stdout, `"name": "endoflife:phase"`) {
+		t.Errorf("expected annotated SBOM on stdout, got %d:\n%s", code, stdout)
+	}
+}
+
+func TestScanSBOMCmd_SPDX(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "bom.spdx")
+	spdx := `SPDXVersion: SPDX-2.3
+SPDXID: SPDXRef-DOCUMENT
+
+PackageName: django
+SPDXID: SPDXRef-Package-django
+PackageVersion: 3.2.25
+ExternalRef: PACKAGE-MANAGER purl pkg:pypi/django@3.2.25
+`
+	if err := os.WriteFile(path, []byte(spdx), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, server.URL, "scan", "sbom", path, "-o", "-", "--at", "2025-01-15")
+	if code != exitEOL {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitEOL, code, stderr)
+	}
+	if !strings.HasPrefix(stdout, spdx) || !strings.Contains(stdout, "AnnotationComment: <text>endoflife:eol=2024-04-01</text>") {
+		t.Errorf("expected annotated SPDX document, got:\n%s", stdout)
+	}
+}
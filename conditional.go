@@ -0,0 +1,31 @@
+package endoflife
+
+import (
+	"net/http"
+	"time"
+)
+
+// setConditionalHeaders adds If-None-Match and If-Modified-Since headers
+// for the validators of the given entry to the request.
+func setConditionalHeaders(req *http.Request, entry *CacheEntry) {
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
+
+// revalidated returns a copy of entry refreshed by a 304 Not Modified
+// response, which may carry updated validators.
+func revalidated(entry *CacheEntry, header http.Header) *CacheEntry {
+	fresh := *entry
+	fresh.StoredAt = time.Now()
+	if etag := header.Get("ETag"); etag != "" {
+		fresh.ETag = etag
+	}
+	if lastModified := header.Get("Last-Modified"); lastModified != "" {
+		fresh.LastModified = lastModified
+	}
+	return &fresh
+}
@@ -0,0 +1,92 @@
+package endoflife
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	const lastModified = "Mon, 06 Jan 2025 00:00:00 GMT"
+	var calls atomic.Int32
+
+	client, server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+				t.Error("expected first request to be unconditional")
+			}
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Last-Modified", lastModified)
+			w.Write([]byte(`{"schema_version":"1.2.0","last_modified":"2025-01-06","result":{"name":"python"}}`))
+			return
+		}
+
+		if r.Header.Get("If-None-Match") != `"v1"` {
+			t.Errorf("unexpected If-None-Match header: %s", r.Header.Get("If-None-Match"))
+		}
+		if r.Header.Get("If-Modified-Since") != lastModified {
+			t.Errorf("unexpected If-Modified-Since header: %s", r.Header.Get("If-Modified-Since"))
+		}
+		w.WriteHeader(http.StatusNotModified)
+	})
+	defer server.Close()
+	WithConditionalRequests()(client)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		result, err := client.GetProduct(ctx, "python")
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i+1, err)
+		}
+		if result.Result.Name != "python" {
+			t.Errorf("request %d: expected product name 'python', got %s", i+1, result.Result.Name)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got %d", calls.Load())
+	}
+}
+
+func TestConditionalRequests_PerURL(t *testing.T) {
+	client, server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/products/go" && r.Header.Get("If-None-Match") != "" {
+			t.Error("expected validators to be scoped to the request URL")
+		}
+		w.Header().Set("ETag", `"`+r.URL.Path+`"`)
+		w.Write([]byte(`{"result":{"name":"x"}}`))
+	})
+	defer server.Close()
+	WithConditionalRequests()(client)
+
+	ctx := context.Background()
+	if _, err := client.GetProduct(ctx, "python"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetProduct(ctx, "go"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestConditionalRequests_Disabled(t *testing.T) {
+	var calls atomic.Int32
+	client, server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("If-None-Match") != "" {
+			t.Error("expected no conditional headers by default")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"result":{"name":"python"}}`))
+	})
+	defer server.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetProduct(ctx, "python"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got %d", calls.Load())
+	}
+}
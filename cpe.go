@@ -0,0 +1,406 @@
+package endoflife
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Logical values of CPE attributes.
+const (
+	// CPEAny is the ANY value, which matches any value.
+	CPEAny = "*"
+
+	// CPENA is the NA value, meaning that the attribute does not apply.
+	CPENA = "-"
+)
+
+// CPE is a Common Platform Enumeration name, such as
+// cpe:2.3:o:canonical:ubuntu_linux:22.04:*:*:*:lts:*:*:*. Attribute values
+// are lower-cased and encoded as in the CPE 2.3 formatted string binding:
+// CPEAny or CPENA, or a value in which special characters are escaped with
+// a backslash, and in which unescaped * and ? are wildcards matching any
+// number of characters and a single character.
+type CPE struct {
+	Part      string // a for applications, o for operating systems, h for hardware
+	Vendor    string
+	Product   string
+	Version   string
+	Update    string
+	Edition   string
+	Language  string
+	SWEdition string
+	TargetSW  string
+	TargetHW  string
+	Other     string
+
+	// wildcards are the compiled patterns of the values with wildcards, as
+	// parsed by ParseCPE. Values missing from it are compiled on use.
+	wildcards map[string]*regexp.Regexp
+}
+
+// ParseCPE parses a CPE name in the 2.3 formatted string binding, such as
+// cpe:2.3:a:python:python:3.12.4:*:*:*:*:*:*:*, or in the 2.2 URI binding,
+// such as cpe:/o:canonical:ubuntu_linux:22.04. Trailing attributes may be
+// omitted and are then ANY, but the part, vendor and product are required.
+func ParseCPE(s string) (*CPE, error) {
+	var values []string
+	switch {
+	case hasPrefixFold(s, "cpe:2.3:"):
+		values = splitCPEFormattedString(s[len("cpe:2.3:"):])
+		if len(values) > 11 {
+			return nil, fmt.Errorf("invalid CPE %q: too many attributes", s)
+		}
+	case hasPrefixFold(s, "cpe:/"):
+		var err error
+		values, err = parseCPEURI(s[len("cpe:/"):])
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPE %q: %w", s, err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid CPE %q: expected cpe:2.3: or cpe:/ prefix", s)
+	}
+	if len(values) < 3 {
+		return nil, fmt.Errorf("invalid CPE %q: missing vendor or product", s)
+	}
+
+	for len(values) < 11 {
+		values = append(values, CPEAny)
+	}
+	for i, v := range values {
+		if v == "" {
+			v = CPEAny
+		}
+		values[i] = strings.ToLower(v)
+	}
+	c := &CPE{
+		Part: values[0], Vendor: values[1], Product: values[2], Version: values[3],
+		Update: values[4], Edition: values[5], Language: values[6], SWEdition: values[7],
+		TargetSW: values[8], TargetHW: values[9], Other: values[10],
+	}
+	switch c.Part {
+	case "a", "o", "h", CPEAny:
+	default:
+		return nil, fmt.Errorf("invalid CPE %q: unknown part %q", s, c.Part)
+	}
+	for _, v := range c.values() {
+		if v != CPEAny && hasCPEWildcard(v) {
+			if c.wildcards == nil {
+				c.wildcards = make(map[string]*regexp.Regexp)
+			}
+			c.wildcards[v] = compileCPEWildcard(v)
+		}
+	}
+	return c, nil
+}
+
+// hasPrefixFold reports whether s begins with prefix, ignoring case.
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// splitCPEFormattedString splits the attributes of a formatted string on
+// colons that are not escaped with a backslash.
+func splitCPEFormattedString(s string) []string {
+	var values []string
+	var current strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			current.WriteByte(s[i])
+			current.WriteByte(s[i+1])
+			i++
+		case s[i] == ':':
+			values = append(values, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(s[i])
+		}
+	}
+	return append(values, current.String())
+}
+
+// parseCPEURI parses the attributes of a URI binding into formatted string
+// values. A packed edition, such as ~~~android~~, holds the edition and the
+// extended attributes of CPE 2.3.
+func parseCPEURI(s string) ([]string, error) {
+	fields := strings.Split(s, ":")
+	if len(fields) > 7 {
+		return nil, fmt.Errorf("too many attributes")
+	}
+	var extended []string
+	if len(fields) > 5 && strings.HasPrefix(fields[5], "~") {
+		packed := strings.Split(fields[5][1:], "~")
+		if len(packed) != 5 {
+			return nil, fmt.Errorf("invalid packed edition %q", fields[5])
+		}
+		fields[5], extended = packed[0], packed[1:]
+	}
+
+	values := make([]string, 0, 11)
+	for _, f := range fields {
+		v, err := uriToFormattedString(f)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	if extended != nil {
+		// The extended attributes come after the language.
+		for len(values) < 7 {
+			values = append(values, CPEAny)
+		}
+		for _, f := range extended {
+			v, err := uriToFormattedString(f)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// uriToFormattedString converts a value of the URI binding to the formatted
+// string binding: percent-encoded characters are decoded, %01 and %02 are
+// the ? and * wildcards, and characters other than letters, digits, '_',
+// '.' and '-' are escaped.
+func uriToFormattedString(v string) (string, error) {
+	switch v {
+	case "":
+		return CPEAny, nil
+	case "-":
+		return CPENA, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		if c == '%' {
+			if i+2 >= len(v) {
+				return "", fmt.Errorf("invalid escape in %q", v)
+			}
+			switch strings.ToLower(v[i : i+3]) {
+			case "%01":
+				b.WriteByte('?')
+				i += 2
+				continue
+			case "%02":
+				b.WriteByte('*')
+				i += 2
+				continue
+			}
+			decoded, err := url.PathUnescape(v[i : i+3])
+			if err != nil {
+				return "", err
+			}
+			c = decoded[0]
+			i += 2
+		}
+		if isAlnum(c) || c == '_' || c == '.' || c == '-' {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
+
+// String returns the CPE name in the 2.3 formatted string binding.
+func (c *CPE) String() string {
+	return "cpe:2.3:" + strings.Join(c.values(), ":")
+}
+
+// values returns the attribute values in order.
+func (c *CPE) values() []string {
+	return []string{
+		c.Part, c.Vendor, c.Product, c.Version, c.Update, c.Edition,
+		c.Language, c.SWEdition, c.TargetSW, c.TargetHW, c.Other,
+	}
+}
+
+// ConcreteVersion returns the unescaped version of the CPE name, or "" if
+// the version is ANY, NA or contains wildcards.
+func (c *CPE) ConcreteVersion() string {
+	if c.Version == CPEAny || c.Version == CPENA || hasCPEWildcard(c.Version) {
+		return ""
+	}
+	return unescapeCPE(c.Version)
+}
+
+// Matches reports whether two CPE names may refer to the same platform:
+// for each attribute, either value is ANY, both are NA, or both are values
+// and are equal, or one has wildcards matching the other.
+func (c *CPE) Matches(other *CPE) bool {
+	a, b := c.values(), other.values()
+	for i := range a {
+		if !matchCPEValue(c, a[i], other, b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchCPEValue reports whether two attribute values, a of ca and b of cb,
+// match.
+func matchCPEValue(ca *CPE, a string, cb *CPE, b string) bool {
+	switch {
+	case a == CPEAny || b == CPEAny:
+		return true
+	case a == CPENA || b == CPENA:
+		return a == b
+	case hasCPEWildcard(a):
+		return ca.wildcard(a).MatchString(unescapeCPE(b))
+	case hasCPEWildcard(b):
+		return cb.wildcard(b).MatchString(unescapeCPE(a))
+	default:
+		return unescapeCPE(a) == unescapeCPE(b)
+	}
+}
+
+// wildcard returns the pattern of a value of the CPE name with wildcards,
+// compiling it unless ParseCPE did.
+func (c *CPE) wildcard(v string) *regexp.Regexp {
+	if re, ok := c.wildcards[v]; ok {
+		return re
+	}
+	return compileCPEWildcard(v)
+}
+
+// hasCPEWildcard reports whether a value contains an unescaped * or ?.
+func hasCPEWildcard(v string) bool {
+	for i := 0; i < len(v); i++ {
+		switch v[i] {
+		case '\\':
+			i++
+		case '*', '?':
+			return true
+		}
+	}
+	return false
+}
+
+// unescapeCPE removes the escaping backslashes of a value.
+func unescapeCPE(v string) string {
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		if v[i] == '\\' && i+1 < len(v) {
+			i++
+		}
+		b.WriteByte(v[i])
+	}
+	return b.String()
+}
+
+// compileCPEWildcard returns a regular expression matching the unescaped
+// values that a value with wildcards matches.
+func compileCPEWildcard(v string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(v); i++ {
+		switch {
+		case v[i] == '\\' && i+1 < len(v):
+			i++
+			b.WriteString(regexp.QuoteMeta(v[i : i+1]))
+		case v[i] == '*':
+			b.WriteString(".*")
+		case v[i] == '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(v[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// CPEResolver finds the products whose cpe identifiers match CPE names, and
+// resolves CPE versions to releases.
+type CPEResolver struct {
+	api         API
+	identifiers []cpeIdentifier
+}
+
+// cpeIdentifier is a cpe identifier of a product.
+type cpeIdentifier struct {
+	cpe     *CPE
+	product string
+}
+
+// NewCPEResolver retrieves the cpe identifiers of products with
+// GetIdentifierDetails. Products are later retrieved from the same API.
+func NewCPEResolver(ctx context.Context, api API) (*CPEResolver, error) {
+	r := &CPEResolver{api: api}
+	ids, err := api.GetIdentifierDetails(ctx, "cpe")
+	if IsNotFound(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range ids.Result {
+		r.add(m.Identifier, m.Product.Name)
+	}
+	return r, nil
+}
+
+// NewCPEResolverFromProducts uses the cpe identifiers of products, as
+// returned by GetProductsFull, instead of retrieving them. Products are
+// later retrieved from api.
+func NewCPEResolverFromProducts(api API, products []ProductDetails) *CPEResolver {
+	r := &CPEResolver{api: api}
+	for _, p := range products {
+		for _, id := range p.Identifiers {
+			if id.Type == "cpe" {
+				r.add(id.ID, p.Name)
+			}
+		}
+	}
+	return r
+}
+
+// add adds an identifier of a product, skipping invalid ones.
+func (r *CPEResolver) add(id, product string) {
+	if c, err := ParseCPE(id); err == nil {
+		r.identifiers = append(r.identifiers, cpeIdentifier{cpe: c, product: product})
+	}
+}
+
+// Product returns the name of the product with the first cpe identifier
+// matching a CPE name, as reported by CPE.Matches, or "" if none does.
+// Identifiers typically only give the part, vendor and product, so any
+// version matches them.
+func (r *CPEResolver) Product(c *CPE) string {
+	for _, id := range r.identifiers {
+		if id.cpe.Matches(c) {
+			return id.product
+		}
+	}
+	return ""
+}
+
+// Resolve parses a CPE name, finds the product it belongs to and, if the
+// name has a concrete version, resolves it to a release with LookupRelease.
+// The release is nil if the name has no version. If no product matches the
+// name, the returned error wraps ErrNoMatchingProduct.
+func (r *CPEResolver) Resolve(ctx context.Context, cpe string) (product string, release *ProductRelease, err error) {
+	c, err := ParseCPE(cpe)
+	if err != nil {
+		return "", nil, err
+	}
+	product = r.Product(c)
+	if product == "" {
+		return "", nil, fmt.Errorf("%w: %s", ErrNoMatchingProduct, cpe)
+	}
+	version := c.ConcreteVersion()
+	if version == "" {
+		return product, nil, nil
+	}
+	release, err = LookupRelease(ctx, r.api, product, version)
+	if err != nil {
+		return product, nil, err
+	}
+	return product, release, nil
+}
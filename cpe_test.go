@@ -0,0 +1,148 @@
+package endoflife
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParseCPE(t *testing.T) {
+	tests := []struct {
+		cpe      string
+		expected string
+	}{
+		{cpe: "cpe:2.3:a:python:python", expected: "cpe:2.3:a:python:python:*:*:*:*:*:*:*:*"},
+		{cpe: "cpe:2.3:o:Canonical:Ubuntu_Linux:22.04:*:*:*:lts:*:*:*", expected: "cpe:2.3:o:canonical:ubuntu_linux:22.04:*:*:*:lts:*:*:*"},
+		{cpe: `cpe:2.3:a:vendor:name\:with\:colons:1.0:-`, expected: `cpe:2.3:a:vendor:name\:with\:colons:1.0:-:*:*:*:*:*:*`},
+		{cpe: "cpe:/o:canonical:ubuntu_linux:20.04", expected: "cpe:2.3:o:canonical:ubuntu_linux:20.04:*:*:*:*:*:*:*"},
+		{cpe: "cpe:/a:nodejs:node.js::-", expected: "cpe:2.3:a:nodejs:node.js:*:-:*:*:*:*:*:*"},
+		{cpe: "cpe:/a:vendor:a%21b:1.%02", expected: `cpe:2.3:a:vendor:a\!b:1.*:*:*:*:*:*:*:*`},
+		{cpe: "cpe:/a:google:chrome:1.0::~~~android~~:en", expected: "cpe:2.3:a:google:chrome:1.0:*:*:en:*:android:*:*"},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseCPE(tt.cpe)
+		if err != nil {
+			t.Errorf("ParseCPE(%q): unexpected error: %v", tt.cpe, err)
+			continue
+		}
+		if s := c.String(); s != tt.expected {
+			t.Errorf("ParseCPE(%q): expected %s, got %s", tt.cpe, tt.expected, s)
+		}
+	}
+
+	for _, cpe := range []string{"", "python", "cpe:2.3:a", "cpe:/a:vendor", "cpe:2.3:x:vendor:product", "cpe:2.3:a:b:c:d:e:f:g:h:i:j:k:l", "cpe:/a:vendor:product:1.%zz"} {
+		if _, err := ParseCPE(cpe); err == nil {
+			t.Errorf("ParseCPE(%q): expected error", cpe)
+		}
+	}
+}
+
+func TestCPE_ConcreteVersion(t *testing.T) {
+	tests := map[string]string{
+		"cpe:2.3:a:python:python:3.12.4":  "3.12.4",
+		`cpe:2.3:a:vendor:product:1.0\+1`: "1.0+1",
+		"cpe:2.3:a:python:python:*":       "",
+		"cpe:2.3:a:python:python:-":       "",
+		"cpe:2.3:a:python:python:3.12.*":  "",
+	}
+	for cpe, expected := range tests {
+		c, err := ParseCPE(cpe)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v := c.ConcreteVersion(); v != expected {
+			t.Errorf("ConcreteVersion(%s): expected %q, got %q", cpe, expected, v)
+		}
+	}
+}
+
+func TestCPE_Matches(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: "cpe:2.3:a:python:python", b: "cpe:2.3:a:python:python:3.12.4:*:*:*:*:*:*:*", expected: true},
+		{a: "cpe:/o:canonical:ubuntu_linux", b: "cpe:2.3:o:canonical:ubuntu_linux:22.04:*:*:*:lts:*:*:*", expected: true},
+		{a: "cpe:2.3:a:python:python", b: "cpe:2.3:o:python:python", expected: false},
+		{a: "cpe:2.3:*:python:python", b: "cpe:2.3:a:python:python", expected: true},
+		{a: "cpe:2.3:a:*:python", b: "cpe:2.3:a:python:python", expected: true},
+		{a: "cpe:2.3:a:python:py*", b: "cpe:2.3:a:python:python", expected: true},
+		{a: "cpe:2.3:a:python:pytho?", b: "cpe:2.3:a:python:python", expected: true},
+		{a: "cpe:2.3:a:python:py?", b: "cpe:2.3:a:python:python", expected: false},
+		{a: `cpe:2.3:a:python:py\*`, b: "cpe:2.3:a:python:python", expected: false},
+		{a: "cpe:2.3:a:python:python:-", b: "cpe:2.3:a:python:python:-", expected: true},
+		{a: "cpe:2.3:a:python:python:-", b: "cpe:2.3:a:python:python:3.12", expected: false},
+		{a: "cpe:2.3:a:python:python:3.11", b: "cpe:2.3:a:python:python:3.12", expected: false},
+	}
+	for _, tt := range tests {
+		a, err := ParseCPE(tt.a)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := ParseCPE(tt.b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if a.Matches(b) != tt.expected || b.Matches(a) != tt.expected {
+			t.Errorf("%s matches %s: expected %v", tt.a, tt.b, tt.expected)
+		}
+	}
+}
+
+func TestCPE_MatchesWildcards(t *testing.T) {
+	a, err := ParseCPE("cpe:2.3:a:python:py*:3.12.*")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(a.wildcards) != 2 || a.wildcards["py*"] == nil || a.wildcards["3.12.*"] == nil {
+		t.Errorf("expected wildcard patterns to be compiled by ParseCPE, got %v", a.wildcards)
+	}
+
+	b := &CPE{Part: "a", Vendor: "python", Product: "python", Version: "3.12.4"}
+	if !a.Matches(b) {
+		t.Errorf("expected %s to match %s", a, b)
+	}
+
+	// CPEs built by hand have their patterns compiled on use.
+	c := &CPE{Part: "a", Vendor: "python", Product: "pyth?n", Version: CPEAny}
+	if !c.Matches(b) || b.Matches(&CPE{Part: "a", Vendor: "python", Product: "py?"}) {
+		t.Error("unexpected match of CPE built by hand")
+	}
+}
+
+func TestCPEResolver_Resolve(t *testing.T) {
+	ctx := context.Background()
+	snapshot := testSnapshot()
+	r, err := NewCPEResolver(ctx, snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	product, release, err := r.Resolve(ctx, "cpe:2.3:o:canonical:ubuntu_linux:24.04:*:*:*:lts:*:*:*")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product != "ubuntu" || release.Name != "24.04" {
+		t.Errorf("expected ubuntu 24.04, got %s %v", product, release)
+	}
+
+	product, release, err = r.Resolve(ctx, "cpe:/o:canonical:ubuntu_linux")
+	if err != nil || product != "ubuntu" || release != nil {
+		t.Errorf("expected ubuntu without release, got %s %v (%v)", product, release, err)
+	}
+
+	if _, _, err := r.Resolve(ctx, "cpe:2.3:o:canonical:ubuntu_linux:12.04"); !errors.Is(err, ErrNoMatchingRelease) {
+		t.Errorf("expected ErrNoMatchingRelease, got %v", err)
+	}
+	if _, _, err := r.Resolve(ctx, "cpe:2.3:a:python:python:3.12"); !errors.Is(err, ErrNoMatchingProduct) {
+		t.Errorf("expected ErrNoMatchingProduct, got %v", err)
+	}
+
+	full, _ := snapshot.GetProductsFull(ctx)
+	r = NewCPEResolverFromProducts(snapshot, full.Result)
+	c, _ := ParseCPE("cpe:2.3:o:canonical:*")
+	if p := r.Product(c); p != "ubuntu" {
+		t.Errorf("expected ubuntu, got %q", p)
+	}
+}
@@ -0,0 +1,192 @@
+package endoflifetest
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/shmokmt/endoflife-go"
+)
+
+// ProductBuilder builds product fixtures.
+type ProductBuilder struct {
+	p endoflife.ProductDetails
+}
+
+// Product returns a builder for a product with the given name.
+func Product(name string) *ProductBuilder {
+	uri, _ := url.JoinPath(endoflife.DefaultBaseURL, "products", name)
+	return &ProductBuilder{p: endoflife.ProductDetails{
+		Name:        name,
+		Label:       name,
+		Aliases:     []string{},
+		Tags:        []string{},
+		URI:         uri,
+		Identifiers: []endoflife.Identifier{},
+		Labels:      endoflife.ProductLabels{EOL: "Security Support"},
+		Links:       endoflife.ProductLinks{HTML: "https://endoflife.date/" + name},
+		Releases:    []endoflife.ProductRelease{},
+	}}
+}
+
+// Label sets the display name.
+func (b *ProductBuilder) Label(label string) *ProductBuilder {
+	b.p.Label = label
+	return b
+}
+
+// Category sets the category.
+func (b *ProductBuilder) Category(category string) *ProductBuilder {
+	b.p.Category = category
+	return b
+}
+
+// Aliases adds aliases.
+func (b *ProductBuilder) Aliases(aliases ...string) *ProductBuilder {
+	b.p.Aliases = append(b.p.Aliases, aliases...)
+	return b
+}
+
+// Tags adds tags.
+func (b *ProductBuilder) Tags(tags ...string) *ProductBuilder {
+	b.p.Tags = append(b.p.Tags, tags...)
+	return b
+}
+
+// Identifier adds an identifier of the given type, such as "purl" or "cpe".
+func (b *ProductBuilder) Identifier(identifierType, id string) *ProductBuilder {
+	b.p.Identifiers = append(b.p.Identifiers, endoflife.Identifier{ID: id, Type: identifierType})
+	return b
+}
+
+// Releases adds releases. Releases are listed newest first, so the first
+// release added is the latest one.
+func (b *ProductBuilder) Releases(releases ...*ReleaseBuilder) *ProductBuilder {
+	for _, r := range releases {
+		b.p.Releases = append(b.p.Releases, r.Build())
+	}
+	return b
+}
+
+// Build returns the product.
+func (b *ProductBuilder) Build() endoflife.ProductDetails {
+	return b.p
+}
+
+// ReferenceTime is the time the Is* flags of releases are derived at,
+// unless set with ReleaseBuilder.At. It is fixed so that fixtures do not
+// change as the calendar moves.
+var ReferenceTime = time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+// ReleaseBuilder builds release fixtures. Dates use the YYYY-MM-DD format
+// and the Is* flags are derived from them relative to ReferenceTime, like
+// the API does relative to the current time.
+type ReleaseBuilder struct {
+	r  endoflife.ProductRelease
+	at time.Time
+}
+
+// Release returns a builder for a release with the given name.
+func Release(name string) *ReleaseBuilder {
+	return &ReleaseBuilder{r: endoflife.ProductRelease{Name: name, Label: name}}
+}
+
+// At sets the time the Is* flags are derived at.
+func (b *ReleaseBuilder) At(t time.Time) *ReleaseBuilder {
+	b.at = t
+	return b
+}
+
+// Label sets the display name.
+func (b *ReleaseBuilder) Label(label string) *ReleaseBuilder {
+	b.r.Label = label
+	return b
+}
+
+// Codename sets the codename.
+func (b *ReleaseBuilder) Codename(codename string) *ReleaseBuilder {
+	b.r.Codename = &codename
+	return b
+}
+
+// Released sets the release date.
+func (b *ReleaseBuilder) Released(date string) *ReleaseBuilder {
+	b.r.ReleaseDate = mustDate(date)
+	return b
+}
+
+// LTS marks the release as a long-term support release from the given date.
+func (b *ReleaseBuilder) LTS(date string) *ReleaseBuilder {
+	d := mustDate(date)
+	b.r.LTSFrom = &d
+	return b
+}
+
+// EOAS sets the end of active support date.
+func (b *ReleaseBuilder) EOAS(date string) *ReleaseBuilder {
+	d := mustDate(date)
+	b.r.EOASFrom = &d
+	return b
+}
+
+// EOL sets the end of life date.
+func (b *ReleaseBuilder) EOL(date string) *ReleaseBuilder {
+	d := mustDate(date)
+	b.r.EOLFrom = &d
+	return b
+}
+
+// EOES sets the end of extended support date.
+func (b *ReleaseBuilder) EOES(date string) *ReleaseBuilder {
+	d := mustDate(date)
+	b.r.EOESFrom = &d
+	return b
+}
+
+// Discontinued sets the discontinuation date.
+func (b *ReleaseBuilder) Discontinued(date string) *ReleaseBuilder {
+	d := mustDate(date)
+	b.r.DiscontinuedFrom = &d
+	return b
+}
+
+// Latest sets the latest version of the release.
+func (b *ReleaseBuilder) Latest(name, date string) *ReleaseBuilder {
+	v := &endoflife.ProductVersion{Name: name}
+	if date != "" {
+		d := mustDate(date)
+		v.Date = &d
+	}
+	b.r.Latest = v
+	return b
+}
+
+// Build returns the release.
+func (b *ReleaseBuilder) Build() endoflife.ProductRelease {
+	r := b.r
+	now := b.at
+	if now.IsZero() {
+		now = ReferenceTime
+	}
+	r.IsLTS = r.LTSFrom != nil && !now.Before(r.LTSFrom.Time)
+	r.IsEOAS = passed(r.EOASFrom, now)
+	r.IsEOL = passed(r.EOLFrom, now)
+	r.IsDiscontinued = passed(r.DiscontinuedFrom, now)
+	if r.EOESFrom != nil {
+		isEOES := passed(r.EOESFrom, now)
+		r.IsEOES = &isEOES
+	}
+	r.IsMaintained = !r.IsEOL || (r.IsEOES != nil && !*r.IsEOES)
+	return r
+}
+
+func passed(d *endoflife.Date, now time.Time) bool {
+	return d != nil && !now.Before(d.Time)
+}
+
+func mustDate(s string) endoflife.Date {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic("endoflifetest: invalid date " + s)
+	}
+	return endoflife.Date{Time: t}
+}
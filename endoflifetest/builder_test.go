@@ -0,0 +1,67 @@
+package endoflifetest
+
+import "testing"
+
+func TestReleaseBuilder_Flags(t *testing.T) {
+	r := Release("1").
+		Released("2000-01-01").
+		LTS("2000-06-01").
+		EOAS("2001-01-01").
+		EOL("2002-01-01").
+		EOES("2999-01-01").
+		Build()
+
+	if !r.IsLTS || !r.IsEOAS || !r.IsEOL {
+		t.Errorf("expected LTS, EOAS and EOL flags to be set: %+v", r)
+	}
+	if r.IsEOES == nil || *r.IsEOES {
+		t.Errorf("expected extended support to be ongoing")
+	}
+	if !r.IsMaintained {
+		t.Error("expected release under extended support to be maintained")
+	}
+
+	r = Release("2").Released("2000-01-01").EOL("2999-01-01").Latest("2.1", "2000-02-01").Build()
+	if r.IsEOL || !r.IsMaintained {
+		t.Errorf("expected release to be maintained: %+v", r)
+	}
+	if r.Latest == nil || r.Latest.Name != "2.1" || r.Latest.Date.String() != "2000-02-01" {
+		t.Errorf("unexpected latest version: %+v", r.Latest)
+	}
+}
+
+func TestProductBuilder(t *testing.T) {
+	p := Product("acme").
+		Label("ACME").
+		Category("app").
+		Aliases("acme-app").
+		Tags("acme").
+		Identifier("purl", "pkg:generic/acme").
+		Releases(Release("2"), Release("1")).
+		Build()
+
+	if p.Name != "acme" || p.Label != "ACME" || p.Category != "app" {
+		t.Errorf("unexpected product: %+v", p)
+	}
+	if p.URI != "https://endoflife.date/api/v1/products/acme" {
+		t.Errorf("unexpected URI: %s", p.URI)
+	}
+	if len(p.Identifiers) != 1 || p.Identifiers[0].Type != "purl" {
+		t.Errorf("unexpected identifiers: %+v", p.Identifiers)
+	}
+	if len(p.Releases) != 2 || p.Releases[0].Name != "2" {
+		t.Errorf("unexpected releases: %+v", p.Releases)
+	}
+}
+
+func TestReleaseBuilder_At(t *testing.T) {
+	r := Release("1").Released("2024-01-01").EOL("2025-06-01").Build()
+	if r.IsEOL {
+		t.Errorf("expected release not to be end of life at the reference time: %+v", r)
+	}
+
+	r = Release("1").Released("2024-01-01").EOL("2025-06-01").At(ReferenceTime.AddDate(1, 0, 0)).Build()
+	if !r.IsEOL || r.IsMaintained {
+		t.Errorf("expected release to be end of life a year after the reference time: %+v", r)
+	}
+}
@@ -0,0 +1,136 @@
+package endoflifetest
+
+import "github.com/shmokmt/endoflife-go"
+
+// Fixtures returns canned fixtures for a selection of well-known products.
+// The data is a trimmed-down copy of endoflife.date, with only some of the
+// releases of each product.
+func Fixtures() []endoflife.ProductDetails {
+	return []endoflife.ProductDetails{
+		Product("python").Label("Python").Category("lang").
+			Aliases("cpython").
+			Tags("lang", "python-software-foundation").
+			Identifier("purl", "pkg:docker/library/python").
+			Identifier("cpe", "cpe:2.3:a:python:python").
+			Identifier("repology", "python").
+			Releases(
+				Release("3.13").Released("2024-10-07").EOAS("2026-10-01").EOL("2029-10-31").Latest("3.13.1", "2024-12-03"),
+				Release("3.12").Released("2023-10-02").EOAS("2025-04-02").EOL("2028-10-31").Latest("3.12.8", "2024-12-03"),
+				Release("3.11").Released("2022-10-24").EOAS("2024-04-01").EOL("2027-10-31").Latest("3.11.11", "2024-12-03"),
+				Release("3.9").Released("2020-10-05").EOAS("2022-05-17").EOL("2025-10-31").Latest("3.9.21", "2024-12-03"),
+				Release("3.8").Released("2019-10-14").EOAS("2021-05-03").EOL("2024-10-07").Latest("3.8.20", "2024-09-06"),
+				Release("2.7").Released("2010-07-03").EOAS("2015-07-03").EOL("2020-01-01").Latest("2.7.18", "2020-04-19"),
+			).Build(),
+
+		Product("nodejs").Label("Node.js").Category("framework").
+			Aliases("node").
+			Tags("javascript-runtime", "openjs").
+			Identifier("purl", "pkg:docker/library/node").
+			Identifier("cpe", "cpe:2.3:a:nodejs:node.js").
+			Identifier("repology", "nodejs").
+			Releases(
+				Release("23").Released("2024-10-16").EOAS("2025-04-01").EOL("2025-06-01").Latest("23.4.0", "2024-12-10"),
+				Release("22").Label("22 (Jod)").Codename("Jod").Released("2024-04-24").LTS("2024-10-29").EOAS("2025-10-21").EOL("2027-04-30").Latest("22.12.0", "2024-12-03"),
+				Release("20").Label("20 (Iron)").Codename("Iron").Released("2023-04-18").LTS("2023-10-24").EOAS("2024-10-22").EOL("2026-04-30").Latest("20.18.1", "2024-11-20"),
+				Release("18").Label("18 (Hydrogen)").Codename("Hydrogen").Released("2022-04-19").LTS("2022-10-25").EOAS("2023-10-18").EOL("2025-04-30").Latest("18.20.5", "2024-11-12"),
+				Release("16").Label("16 (Gallium)").Codename("Gallium").Released("2021-04-20").LTS("2021-10-26").EOAS("2022-10-18").EOL("2023-09-11").Latest("16.20.2", "2023-08-08"),
+				Release("14").Label("14 (Fermium)").Codename("Fermium").Released("2020-04-21").LTS("2020-10-27").EOAS("2021-10-19").EOL("2023-04-30").Latest("14.21.3", "2023-02-16"),
+			).Build(),
+
+		Product("go").Label("Go").Category("lang").
+			Aliases("golang").
+			Tags("google", "lang").
+			Identifier("purl", "pkg:docker/library/golang").
+			Identifier("cpe", "cpe:2.3:a:golang:go").
+			Identifier("repology", "go").
+			Releases(
+				Release("1.24").Released("2025-02-11").Latest("1.24.4", "2025-06-05"),
+				Release("1.23").Released("2024-08-13").EOL("2025-08-12").Latest("1.23.10", "2025-06-05"),
+				Release("1.22").Released("2024-02-06").EOL("2025-02-11").Latest("1.22.12", "2025-02-04"),
+				Release("1.21").Released("2023-08-08").EOL("2024-08-13").Latest("1.21.13", "2024-08-06"),
+			).Build(),
+
+		Product("ubuntu").Label("Ubuntu").Category("os").
+			Tags("canonical", "linux-distribution", "os").
+			Identifier("purl", "pkg:docker/library/ubuntu").
+			Identifier("cpe", "cpe:/o:canonical:ubuntu_linux").
+			Releases(
+				Release("24.04").Label("24.04 'Noble Numbat' (LTS)").Codename("Noble Numbat").Released("2024-04-25").LTS("2024-04-25").EOL("2029-05-31").EOES("2036-04-25").Latest("24.04.1", "2024-08-29"),
+				Release("22.04").Label("22.04 'Jammy Jellyfish' (LTS)").Codename("Jammy Jellyfish").Released("2022-04-21").LTS("2022-04-21").EOL("2027-06-01").EOES("2032-04-09").Latest("22.04.5", "2024-09-12"),
+				Release("20.04").Label("20.04 'Focal Fossa' (LTS)").Codename("Focal Fossa").Released("2020-04-23").LTS("2020-04-23").EOL("2025-05-31").EOES("2030-04-23").Latest("20.04.6", "2023-03-23"),
+				Release("18.04").Label("18.04 'Bionic Beaver' (LTS)").Codename("Bionic Beaver").Released("2018-04-26").LTS("2018-04-26").EOL("2023-05-31").EOES("2028-04-26").Latest("18.04.6", "2021-09-17"),
+			).Build(),
+
+		Product("debian").Label("Debian").Category("os").
+			Tags("debian", "linux-distribution", "os").
+			Identifier("purl", "pkg:docker/library/debian").
+			Identifier("cpe", "cpe:/o:debian:debian_linux").
+			Releases(
+				Release("12").Label("12 (Bookworm)").Codename("Bookworm").Released("2023-06-10").EOAS("2026-06-10").EOL("2028-06-30").Latest("12.8", "2024-11-09"),
+				Release("11").Label("11 (Bullseye)").Codename("Bullseye").Released("2021-08-14").EOAS("2024-08-14").EOL("2026-08-31").Latest("11.11", "2024-08-31"),
+				Release("10").Label("10 (Buster)").Codename("Buster").Released("2019-07-06").EOAS("2022-09-10").EOL("2024-06-30").Latest("10.13", "2022-09-10"),
+			).Build(),
+
+		Product("alpine").Label("Alpine Linux").Category("os").
+			Aliases("alpine-linux").
+			Tags("linux-distribution", "os").
+			Identifier("purl", "pkg:docker/library/alpine").
+			Identifier("cpe", "cpe:2.3:o:alpinelinux:alpine_linux").
+			Releases(
+				Release("3.21").Released("2024-12-05").EOL("2026-11-01").Latest("3.21.0", "2024-12-05"),
+				Release("3.20").Released("2024-05-22").EOL("2026-04-01").Latest("3.20.3", "2024-09-06"),
+				Release("3.17").Released("2022-11-22").EOL("2024-11-22").Latest("3.17.10", "2024-07-22"),
+			).Build(),
+
+		Product("postgresql").Label("PostgreSQL").Category("database").
+			Aliases("postgres").
+			Tags("database", "sql").
+			Identifier("purl", "pkg:docker/library/postgres").
+			Identifier("cpe", "cpe:2.3:a:postgresql:postgresql").
+			Identifier("repology", "postgresql").
+			Releases(
+				Release("17").Released("2024-09-26").EOL("2029-11-08").Latest("17.2", "2024-11-21"),
+				Release("16").Released("2023-09-14").EOL("2028-11-09").Latest("16.6", "2024-11-21"),
+				Release("15").Released("2022-10-13").EOL("2027-11-11").Latest("15.10", "2024-11-21"),
+				Release("12").Released("2019-10-03").EOL("2024-11-21").Latest("12.22", "2024-11-21"),
+			).Build(),
+
+		Product("kubernetes").Label("Kubernetes").Category("server-app").
+			Aliases("k8s").
+			Tags("cncf", "linux-foundation").
+			Identifier("purl", "pkg:github/kubernetes/kubernetes").
+			Identifier("cpe", "cpe:2.3:a:kubernetes:kubernetes").
+			Identifier("repology", "kubernetes").
+			Releases(
+				Release("1.32").Released("2024-12-11").EOAS("2025-12-28").EOL("2026-02-28").Latest("1.32.0", "2024-12-11"),
+				Release("1.31").Released("2024-08-13").EOAS("2025-08-28").EOL("2025-10-28").Latest("1.31.4", "2024-12-10"),
+				Release("1.30").Released("2024-04-17").EOAS("2025-04-28").EOL("2025-06-28").Latest("1.30.8", "2024-12-10"),
+				Release("1.29").Released("2023-12-13").EOAS("2024-12-28").EOL("2025-02-28").Latest("1.29.12", "2024-12-10"),
+				Release("1.28").Released("2023-08-15").EOAS("2024-08-28").EOL("2024-10-28").Latest("1.28.15", "2024-10-22"),
+			).Build(),
+
+		Product("terraform").Label("Terraform").Category("app").
+			Tags("hashicorp").
+			Identifier("purl", "pkg:github/hashicorp/terraform").
+			Identifier("cpe", "cpe:2.3:a:hashicorp:terraform").
+			Identifier("repology", "terraform").
+			Releases(
+				Release("1.10").Released("2024-11-27").Latest("1.10.3", "2024-12-18"),
+				Release("1.9").Released("2024-06-26").Latest("1.9.8", "2024-10-16"),
+				Release("1.8").Released("2024-04-10").EOL("2025-11-27").Latest("1.8.5", "2024-06-05"),
+				Release("1.5").Released("2023-06-12").EOL("2024-04-10").Latest("1.5.7", "2023-09-07"),
+			).Build(),
+
+		Product("django").Label("Django").Category("framework").
+			Tags("django-software-foundation", "python-runtime").
+			Identifier("purl", "pkg:pypi/django").
+			Identifier("cpe", "cpe:2.3:a:djangoproject:django").
+			Identifier("repology", "python:django").
+			Releases(
+				Release("5.1").Released("2024-08-07").EOAS("2025-04-02").EOL("2025-12-03").Latest("5.1.4", "2024-12-04"),
+				Release("5.0").Released("2023-12-04").EOAS("2024-08-07").EOL("2025-04-02").Latest("5.0.10", "2024-12-04"),
+				Release("4.2").Released("2023-04-03").LTS("2023-04-03").EOAS("2023-12-04").EOL("2026-04-30").Latest("4.2.17", "2024-12-04"),
+				Release("3.2").Released("2021-04-06").LTS("2021-04-06").EOAS("2021-12-07").EOL("2024-04-01").Latest("3.2.25", "2024-03-04"),
+			).Build(),
+	}
+}
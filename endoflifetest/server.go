@@ -0,0 +1,269 @@
+// Package endoflifetest provides an in-process fake of the endoflife.date
+// API for testing code built on the endoflife package.
+//
+// A Server implements every v1 route used by endoflife.Client. It is seeded
+// with product fixtures, built with Product and Release or taken from
+// Fixtures, or with a snapshot file, and can inject errors and latency:
+//
+//	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+//	defer server.Close()
+//
+//	server.RateLimit("/products/python", 30, 1)
+//	client := server.Client()
+package endoflifetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shmokmt/endoflife-go"
+)
+
+// Server is a fake endoflife.date API server.
+type Server struct {
+	// URL is the base URL of the server, suitable for endoflife.WithBaseURL.
+	URL string
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	products []endoflife.ProductDetails
+	snapshot *endoflife.Snapshot
+	faults   []*fault
+	latency  time.Duration
+	requests map[string]int
+}
+
+// fault is an injected error response.
+type fault struct {
+	pattern    string
+	status     int
+	retryAfter int
+	remaining  int // number of responses left to fail; 0 or less means unlimited
+}
+
+// NewServer starts a Server seeded with the given products. The caller
+// must call Close when finished.
+func NewServer(products ...endoflife.ProductDetails) *Server {
+	s := &Server{requests: make(map[string]int)}
+	s.AddProducts(products...)
+	s.server = httptest.NewServer(s.handler())
+	s.URL = s.server.URL
+	return s
+}
+
+// NewServerFromSnapshot starts a Server seeded with the products of a
+// snapshot file written by endoflife.Client.ExportSnapshot.
+func NewServerFromSnapshot(path string) (*Server, error) {
+	snapshot, err := endoflife.LoadSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	full, err := snapshot.GetProductsFull(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return NewServer(full.Result...), nil
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client for the server. Additional options are applied
+// after the base URL is set.
+func (s *Server) Client(opts ...endoflife.Option) *endoflife.Client {
+	return endoflife.NewClientWithOptions(append([]endoflife.Option{endoflife.WithBaseURL(s.URL)}, opts...)...)
+}
+
+// AddProducts adds products to the server, replacing existing products of
+// the same name.
+func (s *Server) AddProducts(products ...endoflife.ProductDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range products {
+		replaced := false
+		for i := range s.products {
+			if s.products[i].Name == p.Name {
+				s.products[i] = p
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			s.products = append(s.products, p)
+		}
+	}
+	s.snapshot = endoflife.NewSnapshot(&endoflife.FullProductListResponse{
+		SchemaVersion: "1.2.0",
+		Total:         len(s.products),
+		Result:        slices.Clone(s.products),
+	})
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// FailWith makes requests whose path matches pattern fail with the given
+// status code. The pattern uses path.Match syntax; an empty pattern matches
+// every path. Only the next times matching requests fail; if times is zero
+// or less, they fail until Reset is called.
+func (s *Server) FailWith(pattern string, status, times int) {
+	s.addFault(&fault{pattern: pattern, status: status, remaining: times})
+}
+
+// RateLimit makes requests whose path matches pattern fail with 429 Too
+// Many Requests and a Retry-After header of retryAfter seconds. Pattern and
+// times behave as in FailWith.
+func (s *Server) RateLimit(pattern string, retryAfter, times int) {
+	s.addFault(&fault{pattern: pattern, status: http.StatusTooManyRequests, retryAfter: retryAfter, remaining: times})
+}
+
+func (s *Server) addFault(f *fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, f)
+}
+
+// Reset removes injected faults and latency and clears request counts.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+	s.latency = 0
+	s.requests = make(map[string]int)
+}
+
+// Requests returns the number of requests received for the given path.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+// begin records a request and returns its injected fault, if any, and the
+// latency to apply.
+func (s *Server) begin(r *http.Request) (*fault, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[r.URL.Path]++
+	for i, f := range s.faults {
+		if f.pattern != "" {
+			if ok, _ := path.Match(f.pattern, r.URL.Path); !ok {
+				continue
+			}
+		}
+		if f.remaining > 0 {
+			if f.remaining--; f.remaining == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f, s.latency
+	}
+	return nil, s.latency
+}
+
+func (s *Server) current() *endoflife.Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshot
+}
+
+// handler returns the HTTP handler implementing the API routes.
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.serve(func(r *http.Request) (any, error) {
+		return s.current().GetIndex(r.Context())
+	}))
+	mux.HandleFunc("GET /products", s.serve(func(r *http.Request) (any, error) {
+		return s.current().GetProducts(r.Context())
+	}))
+	mux.HandleFunc("GET /products/full", s.serve(func(r *http.Request) (any, error) {
+		return s.current().GetProductsFull(r.Context())
+	}))
+	mux.HandleFunc("GET /products/{product}", s.serve(func(r *http.Request) (any, error) {
+		return s.current().GetProduct(r.Context(), r.PathValue("product"))
+	}))
+	mux.HandleFunc("GET /products/{product}/releases/{release}", s.serve(func(r *http.Request) (any, error) {
+		return s.current().GetRelease(r.Context(), r.PathValue("product"), r.PathValue("release"))
+	}))
+	mux.HandleFunc("GET /categories", s.serve(func(r *http.Request) (any, error) {
+		return s.current().GetCategories(r.Context())
+	}))
+	mux.HandleFunc("GET /categories/{category}", s.serve(func(r *http.Request) (any, error) {
+		return s.current().GetCategoryProducts(r.Context(), r.PathValue("category"))
+	}))
+	mux.HandleFunc("GET /tags", s.serve(func(r *http.Request) (any, error) {
+		return s.current().GetTags(r.Context())
+	}))
+	mux.HandleFunc("GET /tags/{tag}", s.serve(func(r *http.Request) (any, error) {
+		return s.current().GetTagProducts(r.Context(), r.PathValue("tag"))
+	}))
+	mux.HandleFunc("GET /identifiers", s.serve(func(r *http.Request) (any, error) {
+		return s.current().GetIdentifiers(r.Context())
+	}))
+	mux.HandleFunc("GET /identifiers/{type}", s.serve(func(r *http.Request) (any, error) {
+		return s.current().GetIdentifierDetails(r.Context(), r.PathValue("type"))
+	}))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, latency := s.begin(r)
+		if latency > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(latency):
+			}
+		}
+		if f != nil {
+			if f.retryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(f.retryAfter))
+			}
+			writeError(w, f.status)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// serve adapts a query to an HTTP handler writing its result as JSON.
+func (s *Server) serve(query func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := query(r)
+		if err != nil {
+			var apiErr *endoflife.APIError
+			if errors.As(err, &apiErr) {
+				writeError(w, apiErr.StatusCode)
+			} else {
+				writeError(w, http.StatusBadRequest)
+			}
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int) {
+	writeJSON(w, status, map[string]string{"message": http.StatusText(status)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,179 @@
+package endoflifetest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shmokmt/endoflife-go"
+	"github.com/shmokmt/endoflife-go/endoflifetest"
+)
+
+func TestServer_Routes(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	client := server.Client()
+	ctx := context.Background()
+
+	index, err := client.GetIndex(ctx)
+	if err != nil || index.Total == 0 {
+		t.Errorf("GetIndex: unexpected result %v, %v", index, err)
+	}
+	products, err := client.GetProducts(ctx)
+	if err != nil || products.Total != len(endoflifetest.Fixtures()) {
+		t.Errorf("GetProducts: unexpected result %v, %v", products, err)
+	}
+	full, err := client.GetProductsFull(ctx)
+	if err != nil || full.Total != len(endoflifetest.Fixtures()) {
+		t.Errorf("GetProductsFull: unexpected result %v, %v", full, err)
+	}
+	product, err := client.GetProduct(ctx, "python")
+	if err != nil || product.Result.Label != "Python" {
+		t.Errorf("GetProduct: unexpected result %v, %v", product, err)
+	}
+	release, err := client.GetRelease(ctx, "python", "3.12")
+	if err != nil || release.Result.Name != "3.12" {
+		t.Errorf("GetRelease: unexpected result %v, %v", release, err)
+	}
+	latest, err := client.GetLatestRelease(ctx, "python")
+	if err != nil || latest.Result.Name != "3.13" {
+		t.Errorf("GetLatestRelease: unexpected result %v, %v", latest, err)
+	}
+	categories, err := client.GetCategories(ctx)
+	if err != nil || categories.Total == 0 {
+		t.Errorf("GetCategories: unexpected result %v, %v", categories, err)
+	}
+	byCategory, err := client.GetCategoryProducts(ctx, "os")
+	if err != nil || byCategory.Total != 3 {
+		t.Errorf("GetCategoryProducts: unexpected result %v, %v", byCategory, err)
+	}
+	tags, err := client.GetTags(ctx)
+	if err != nil || tags.Total == 0 {
+		t.Errorf("GetTags: unexpected result %v, %v", tags, err)
+	}
+	byTag, err := client.GetTagProducts(ctx, "lang")
+	if err != nil || byTag.Total != 2 {
+		t.Errorf("GetTagProducts: unexpected result %v, %v", byTag, err)
+	}
+	identifiers, err := client.GetIdentifiers(ctx)
+	if err != nil || identifiers.Total != 3 {
+		t.Errorf("GetIdentifiers: unexpected result %v, %v", identifiers, err)
+	}
+	purls, err := client.GetIdentifierDetails(ctx, "purl")
+	if err != nil || purls.Total != len(endoflifetest.Fixtures()) {
+		t.Errorf("GetIdentifierDetails: unexpected result %v, %v", purls, err)
+	}
+
+	if _, err := client.GetProduct(ctx, "nonexistent"); !endoflife.IsNotFound(err) {
+		t.Errorf("expected NotFound error, got %v", err)
+	}
+	if server.Requests("/products/python") != 1 {
+		t.Errorf("expected 1 request for /products/python, got %d", server.Requests("/products/python"))
+	}
+}
+
+func TestServer_Builder(t *testing.T) {
+	server := endoflifetest.NewServer()
+	defer server.Close()
+
+	server.AddProducts(endoflifetest.Product("acme").Label("ACME").Category("app").
+		Releases(endoflifetest.Release("2").Released("2024-01-01")).
+		Build())
+
+	release, err := server.Client().GetLatestRelease(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if release.Result.Name != "2" {
+		t.Errorf("expected release 2, got %s", release.Result.Name)
+	}
+}
+
+func TestServer_FailWith(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	client := server.Client()
+	ctx := context.Background()
+
+	server.FailWith("/products/*", http.StatusInternalServerError, 1)
+	_, err := client.GetProduct(ctx, "python")
+	var apiErr *endoflife.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected 500 error, got %v", err)
+	}
+
+	// The fault only applied once.
+	if _, err := client.GetProduct(ctx, "python"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	server.FailWith("", http.StatusNotFound, 0)
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetProducts(ctx); !endoflife.IsNotFound(err) {
+			t.Errorf("expected NotFound error, got %v", err)
+		}
+	}
+
+	server.Reset()
+	if _, err := client.GetProducts(ctx); err != nil {
+		t.Errorf("unexpected error after Reset: %v", err)
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	server.RateLimit("/products/python", 30, 1)
+	_, err := server.Client().GetProduct(context.Background(), "python")
+
+	var apiErr *endoflife.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if !endoflife.IsRateLimited(err) || apiErr.RetryAfter != 30 {
+		t.Errorf("expected rate limit with RetryAfter 30, got %v", err)
+	}
+}
+
+func TestServer_Latency(t *testing.T) {
+	server := endoflifetest.NewServer(endoflifetest.Fixtures()...)
+	defer server.Close()
+
+	server.SetLatency(200 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := server.Client().GetProducts(ctx); err == nil {
+		t.Error("expected error due to latency")
+	}
+}
+
+func TestNewServerFromSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := endoflife.NewSnapshot(&endoflife.FullProductListResponse{Result: endoflifetest.Fixtures()})
+	if err := snapshot.Write(f); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	server, err := endoflifetest.NewServerFromSnapshot(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer server.Close()
+
+	if _, err := server.Client().GetRelease(context.Background(), "ubuntu", "22.04"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -14,6 +14,14 @@ var (
 
 	// ErrNotModified is returned when the resource has not been modified (304).
 	ErrNotModified = errors.New("resource not modified")
+
+	// ErrNoMatchingRelease is returned when a version does not belong to any
+	// release of a product.
+	ErrNoMatchingRelease = errors.New("no matching release")
+
+	// ErrNoMatchingProduct is returned when an identifier, such as a package
+	// URL, does not belong to any product.
+	ErrNoMatchingProduct = errors.New("no matching product")
 )
 
 // APIError represents an error response from the API.
@@ -32,6 +40,23 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("API error: %d %s", e.StatusCode, e.Message)
 }
 
+// RetryError is returned when a request made under a RetryPolicy fails.
+// It records how many attempts were made and wraps the last error.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+// Error implements the error interface.
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%v (attempts: %d)", e.Err, e.Attempts)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
 // IsNotFound reports whether the error is a 404 error.
 func IsNotFound(err error) bool {
 	if errors.Is(err, ErrNotFound) {
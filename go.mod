@@ -2,4 +2,20 @@ module github.com/shmokmt/endoflife-go
 
 go 1.24.4
 
-require github.com/alecthomas/kong v1.13.0 // indirect
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/alecthomas/kong v1.13.0
+	github.com/hashicorp/hcl/v2 v2.24.0
+	github.com/zclconf/go-cty v1.16.3
+	golang.org/x/mod v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/agext/levenshtein v1.2.1 // indirect
+	github.com/apparentlymart/go-textseg/v15 v15.0.0 // indirect
+	github.com/mitchellh/go-wordwrap v1.0.1 // indirect
+	golang.org/x/sync v0.17.0 // indirect
+	golang.org/x/text v0.25.0 // indirect
+	golang.org/x/tools v0.38.0 // indirect
+)
@@ -0,0 +1,53 @@
+// Package numver implements the handling of numeric version strings shared
+// by the endoflife and scan packages, so that they agree on what a version
+// looks like.
+package numver
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Parse parses a dotted numeric version such as "3.12.4". It returns false
+// if any component is not a number.
+func Parse(v string) ([]int, bool) {
+	if v == "" {
+		return nil, false
+	}
+	fields := strings.Split(v, ".")
+	parts := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// Compare compares two parsed versions component by component, treating
+// missing components as zero.
+func Compare(a, b []int) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// IsDigit reports whether c is an ASCII digit.
+func IsDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,45 @@
+package numver
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected []int
+		ok       bool
+	}{
+		{v: "3.12.4", expected: []int{3, 12, 4}, ok: true},
+		{v: "18", expected: []int{18}, ok: true},
+		{v: "", ok: false},
+		{v: "1.x", ok: false},
+		{v: "1..2", ok: false},
+		{v: "-1", ok: false},
+		{v: "v1.2", ok: false},
+	}
+	for _, tt := range tests {
+		parts, ok := Parse(tt.v)
+		if ok != tt.ok || !slices.Equal(parts, tt.expected) {
+			t.Errorf("Parse(%q): expected %v (%v), got %v (%v)", tt.v, tt.expected, tt.ok, parts, ok)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b     []int
+		expected int
+	}{
+		{a: []int{3, 12}, b: []int{3, 12, 0}, expected: 0},
+		{a: []int{3, 9}, b: []int{3, 12}, expected: -1},
+		{a: []int{4}, b: []int{3, 99, 1}, expected: 1},
+		{a: nil, b: []int{0, 0}, expected: 0},
+	}
+	for _, tt := range tests {
+		if result := Compare(tt.a, tt.b); result != tt.expected {
+			t.Errorf("Compare(%v, %v): expected %d, got %d", tt.a, tt.b, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package endoflife
+
+import (
+	"slices"
+	"time"
+)
+
+// Phase is a lifecycle phase of a release.
+type Phase string
+
+const (
+	// PhaseUnreleased is the phase before the release date.
+	PhaseUnreleased Phase = "unreleased"
+
+	// PhaseActive is the phase of active support.
+	PhaseActive Phase = "active"
+
+	// PhaseSecurity is the phase of security support only, after the end of
+	// active support (EOAS).
+	PhaseSecurity Phase = "security"
+
+	// PhaseDiscontinued is the phase of a still supported release that is
+	// no longer produced or sold, typically a device.
+	PhaseDiscontinued Phase = "discontinued"
+
+	// PhaseExtended is the phase of extended support, after the end of life
+	// but before the end of extended support (EOES).
+	PhaseExtended Phase = "extended"
+
+	// PhaseEOL is the phase after the end of life, and of extended support
+	// if any.
+	PhaseEOL Phase = "eol"
+)
+
+// LifecycleStatus is the lifecycle status of a release at a given date.
+type LifecycleStatus struct {
+	// Phase is the phase at the given date.
+	Phase Phase
+
+	// Next is the phase the release enters next, or "" if no further
+	// transition is known.
+	Next Phase
+
+	// NextDate is the date of the transition to Next, or nil if unknown.
+	NextDate *Date
+
+	// DaysRemaining is the number of days until NextDate, or -1 if unknown.
+	DaysRemaining int
+}
+
+// StatusAt returns the lifecycle status of the release at the given date,
+// derived from ReleaseDate, EOASFrom, DiscontinuedFrom, EOLFrom and EOESFrom.
+//
+// When a date is unknown, the corresponding Is* flag is used instead. Those
+// flags reflect the API's view of the current date, so such a milestone is
+// considered either always or never reached.
+func (r *ProductRelease) StatusAt(t time.Time) LifecycleStatus {
+	status := LifecycleStatus{
+		Phase:         r.phaseAt(t),
+		DaysRemaining: -1,
+	}
+
+	var dates []Date
+	for _, d := range []*Date{&r.ReleaseDate, r.EOASFrom, r.DiscontinuedFrom, r.EOLFrom, r.EOESFrom} {
+		if d != nil && !d.IsZero() && d.After(t) {
+			dates = append(dates, *d)
+		}
+	}
+	slices.SortFunc(dates, func(a, b Date) int { return a.Compare(b.Time) })
+
+	for _, d := range dates {
+		if next := r.phaseAt(d.Time); next != status.Phase {
+			status.Next = next
+			status.NextDate = &d
+			status.DaysRemaining = daysBetween(t, d.Time)
+			break
+		}
+	}
+	return status
+}
+
+// phaseAt returns the phase of the release at the given date.
+func (r *ProductRelease) phaseAt(t time.Time) Phase {
+	eoes := r.IsEOES != nil && *r.IsEOES
+	hasEOES := r.EOESFrom != nil || r.IsEOES != nil
+
+	switch {
+	case !r.ReleaseDate.IsZero() && t.Before(r.ReleaseDate.Time):
+		return PhaseUnreleased
+	case reached(r.EOLFrom, r.IsEOL, t):
+		if hasEOES && !reached(r.EOESFrom, eoes, t) {
+			return PhaseExtended
+		}
+		return PhaseEOL
+	case reached(r.DiscontinuedFrom, r.IsDiscontinued, t):
+		return PhaseDiscontinued
+	case reached(r.EOASFrom, r.IsEOAS, t):
+		return PhaseSecurity
+	default:
+		return PhaseActive
+	}
+}
+
+// reached reports whether a milestone is reached at t, falling back to flag
+// if its date is unknown.
+func reached(d *Date, flag bool, t time.Time) bool {
+	if d == nil || d.IsZero() {
+		return flag
+	}
+	return !t.Before(d.Time)
+}
@@ -0,0 +1,101 @@
+package endoflife
+
+import (
+	"testing"
+	"time"
+)
+
+func date(s string) *Date {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return &Date{Time: t}
+}
+
+func TestProductRelease_StatusAt(t *testing.T) {
+	// Python 3.12: active until 2025-04-02, then security only until 2028-10-31.
+	python := &ProductRelease{
+		Name:        "3.12",
+		ReleaseDate: *date("2023-10-02"),
+		EOASFrom:    date("2025-04-02"),
+		EOLFrom:     date("2028-10-31"),
+	}
+	// Ubuntu 22.04: standard support until 2027-06-01, ESM until 2032-04-09.
+	ubuntu := &ProductRelease{
+		Name:        "22.04",
+		ReleaseDate: *date("2022-04-21"),
+		EOLFrom:     date("2027-06-01"),
+		EOESFrom:    date("2032-04-09"),
+	}
+	// A device discontinued before the end of its support.
+	device := &ProductRelease{
+		Name:             "phone",
+		ReleaseDate:      *date("2020-01-01"),
+		DiscontinuedFrom: date("2021-01-01"),
+		EOLFrom:          date("2025-01-01"),
+	}
+
+	tests := []struct {
+		name          string
+		release       *ProductRelease
+		at            string
+		phase         Phase
+		next          Phase
+		nextDate      string
+		daysRemaining int
+	}{
+		{name: "unreleased", release: python, at: "2023-09-01", phase: PhaseUnreleased, next: PhaseActive, nextDate: "2023-10-02", daysRemaining: 31},
+		{name: "active", release: python, at: "2025-03-01", phase: PhaseActive, next: PhaseSecurity, nextDate: "2025-04-02", daysRemaining: 32},
+		{name: "security", release: python, at: "2027-03-01", phase: PhaseSecurity, next: PhaseEOL, nextDate: "2028-10-31", daysRemaining: 610},
+		{name: "eol on the day", release: python, at: "2028-10-31", phase: PhaseEOL, daysRemaining: -1},
+		{name: "extended", release: ubuntu, at: "2030-01-01", phase: PhaseExtended, next: PhaseEOL, nextDate: "2032-04-09", daysRemaining: 829},
+		{name: "active before extended", release: ubuntu, at: "2027-05-31", phase: PhaseActive, next: PhaseExtended, nextDate: "2027-06-01", daysRemaining: 1},
+		{name: "after extended", release: ubuntu, at: "2033-01-01", phase: PhaseEOL, daysRemaining: -1},
+		{name: "discontinued", release: device, at: "2022-01-01", phase: PhaseDiscontinued, next: PhaseEOL, nextDate: "2025-01-01", daysRemaining: 1096},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.release.StatusAt(date(tt.at).Time)
+			if status.Phase != tt.phase {
+				t.Errorf("expected phase %s, got %s", tt.phase, status.Phase)
+			}
+			if status.Next != tt.next {
+				t.Errorf("expected next phase %q, got %q", tt.next, status.Next)
+			}
+			if tt.nextDate == "" && status.NextDate != nil {
+				t.Errorf("expected no next date, got %s", status.NextDate)
+			}
+			if tt.nextDate != "" && (status.NextDate == nil || status.NextDate.String() != tt.nextDate) {
+				t.Errorf("expected next date %s, got %v", tt.nextDate, status.NextDate)
+			}
+			if status.DaysRemaining != tt.daysRemaining {
+				t.Errorf("expected %d days remaining, got %d", tt.daysRemaining, status.DaysRemaining)
+			}
+		})
+	}
+}
+
+func TestProductRelease_StatusAt_FlagFallback(t *testing.T) {
+	isEOES := false
+	release := &ProductRelease{
+		Name:   "1",
+		IsEOAS: true,
+		IsEOL:  true,
+		IsEOES: &isEOES,
+	}
+
+	status := release.StatusAt(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	if status.Phase != PhaseExtended {
+		t.Errorf("expected phase %s, got %s", PhaseExtended, status.Phase)
+	}
+	if status.Next != "" || status.DaysRemaining != -1 {
+		t.Errorf("expected no known transition, got %+v", status)
+	}
+
+	release = &ProductRelease{Name: "2", EOLFrom: date("2030-01-01")}
+	if phase := release.StatusAt(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)).Phase; phase != PhaseActive {
+		t.Errorf("expected phase %s, got %s", PhaseActive, phase)
+	}
+}
@@ -0,0 +1,108 @@
+package endoflife
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shmokmt/endoflife-go/internal/numver"
+)
+
+// PatchStatus describes how an installed version compares with the latest
+// version of its release, as reported by ProductRelease.Latest.
+type PatchStatus struct {
+	// Installed is the normalized installed version.
+	Installed string
+
+	// Latest is the latest version of the release.
+	Latest string
+
+	// UpToDate reports whether the installed version is the latest one, or
+	// newer.
+	UpToDate bool
+
+	// PatchesBehind is the difference in the first version component after
+	// the release cycle in which the installed and latest versions differ,
+	// for example 3 for 3.12.1 and 3.12.4 of the release 3.12. Since the
+	// API only reports the latest version, it estimates the number of
+	// missed patch releases.
+	PatchesBehind int
+
+	// DaysBehind is the number of days since the latest version was
+	// released, or zero if up to date or the release date is unknown.
+	DaysBehind int
+
+	// Link is the link to the release notes of the latest version, if any.
+	Link string
+}
+
+// CheckPatchLevel compares an installed version with the latest version of
+// the release it belongs to, typically found with ResolveRelease. The
+// installed version is normalized with NormalizeVersion. DaysBehind is
+// computed as of now.
+//
+// If the installed version does not belong to the release, that is it does
+// not start with the numeric release name, the returned error wraps
+// ErrNoMatchingRelease.
+func CheckPatchLevel(installed string, release *ProductRelease, now time.Time) (*PatchStatus, error) {
+	if release.Latest == nil {
+		return nil, fmt.Errorf("release %s has no latest version", release.Name)
+	}
+
+	status := &PatchStatus{
+		Installed: NormalizeVersion(installed),
+		Latest:    NormalizeVersion(release.Latest.Name),
+	}
+	if release.Latest.Link != nil {
+		status.Link = *release.Latest.Link
+	}
+
+	have, ok := numver.Parse(status.Installed)
+	if !ok {
+		return nil, fmt.Errorf("invalid installed version %q", installed)
+	}
+	want, ok := numver.Parse(status.Latest)
+	if !ok {
+		return nil, fmt.Errorf("invalid latest version %q", release.Latest.Name)
+	}
+
+	// Versions are compared after the release cycle, whose components
+	// they share. Releases with non-numeric names are compared in full.
+	cycle, ok := numver.Parse(strings.TrimPrefix(strings.ToLower(release.Name), "v"))
+	if !ok {
+		cycle = nil
+	}
+	if !hasVersionPrefix(have, cycle) {
+		return nil, fmt.Errorf("%w: %s is not a version of release %s", ErrNoMatchingRelease, status.Installed, release.Name)
+	}
+
+	status.UpToDate = true
+	for i := len(cycle); i < max(len(have), len(want)); i++ {
+		var h, w int
+		if i < len(have) {
+			h = have[i]
+		}
+		if i < len(want) {
+			w = want[i]
+		}
+		if h != w {
+			if h < w {
+				status.UpToDate = false
+				status.PatchesBehind = w - h
+			}
+			break
+		}
+	}
+
+	if !status.UpToDate && release.Latest.Date != nil && !release.Latest.Date.IsZero() {
+		status.DaysBehind = max(daysBetween(release.Latest.Date.Time, now), 0)
+	}
+	return status, nil
+}
+
+// daysBetween returns the number of calendar days from a to b.
+func daysBetween(a, b time.Time) int {
+	a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
@@ -0,0 +1,85 @@
+package endoflife
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckPatchLevel(t *testing.T) {
+	link := "https://www.python.org/downloads/release/python-3128/"
+	release := &ProductRelease{
+		Name: "3.12",
+		Latest: &ProductVersion{
+			Name: "3.12.8",
+			Date: &Date{Time: time.Date(2024, 12, 3, 0, 0, 0, 0, time.UTC)},
+			Link: &link,
+		},
+	}
+	now := time.Date(2025, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		installed     string
+		upToDate      bool
+		patchesBehind int
+		daysBehind    int
+	}{
+		{name: "latest", installed: "3.12.8", upToDate: true},
+		{name: "newer", installed: "3.12.9", upToDate: true},
+		{name: "behind", installed: "python 3.12.4", patchesBehind: 4, daysBehind: 30},
+		{name: "cycle only", installed: "3.12", patchesBehind: 8, daysBehind: 30},
+		{name: "build behind", installed: "3.12.8.0", upToDate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := CheckPatchLevel(tt.installed, release, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if status.UpToDate != tt.upToDate {
+				t.Errorf("expected UpToDate %v, got %v", tt.upToDate, status.UpToDate)
+			}
+			if status.PatchesBehind != tt.patchesBehind {
+				t.Errorf("expected PatchesBehind %d, got %d", tt.patchesBehind, status.PatchesBehind)
+			}
+			if status.DaysBehind != tt.daysBehind {
+				t.Errorf("expected DaysBehind %d, got %d", tt.daysBehind, status.DaysBehind)
+			}
+			if status.Latest != "3.12.8" || status.Link != link {
+				t.Errorf("unexpected latest version: %+v", status)
+			}
+		})
+	}
+}
+
+func TestCheckPatchLevel_Errors(t *testing.T) {
+	now := time.Now()
+
+	if _, err := CheckPatchLevel("3.12.1", &ProductRelease{Name: "3.12"}, now); err == nil {
+		t.Error("expected error for release without latest version")
+	}
+
+	release := &ProductRelease{Name: "3.12", Latest: &ProductVersion{Name: "3.12.8"}}
+	if _, err := CheckPatchLevel("unknown", release, now); err == nil {
+		t.Error("expected error for invalid installed version")
+	}
+
+	for _, installed := range []string{"3.11.9", "3.13.0", "4"} {
+		if _, err := CheckPatchLevel(installed, release, now); !errors.Is(err, ErrNoMatchingRelease) {
+			t.Errorf("CheckPatchLevel(%q): expected ErrNoMatchingRelease, got %v", installed, err)
+		}
+	}
+}
+
+func TestCheckPatchLevel_MajorRelease(t *testing.T) {
+	release := &ProductRelease{Name: "8", Latest: &ProductVersion{Name: "8.0.392"}}
+	status, err := CheckPatchLevel("8.0.382", release, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.UpToDate || status.PatchesBehind != 10 {
+		t.Errorf("expected 10 patches behind, got %+v", status)
+	}
+}
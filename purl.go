@@ -0,0 +1,293 @@
+package endoflife
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/shmokmt/endoflife-go/internal/numver"
+)
+
+// PackageURL is a package URL (purl), such as pkg:npm/%40angular/core@17.1.0,
+// as defined by the purl specification. Components are unescaped.
+type PackageURL struct {
+	// Type is the package type, such as npm, pypi or docker.
+	Type string
+
+	// Namespace is the namespace of the package, if any, such as a Maven
+	// group or an npm scope. Its segments are separated by "/".
+	Namespace string
+
+	// Name is the name of the package.
+	Name string
+
+	// Version is the version of the package, if any.
+	Version string
+
+	// Qualifiers are extra qualifying data, such as arch or distro.
+	Qualifiers map[string]string
+
+	// Subpath is a path within the package, if any.
+	Subpath string
+}
+
+// lowercasedPurlTypes are the package types whose namespace and name are
+// case-insensitive, and lower-cased by ParsePackageURL.
+var lowercasedPurlTypes = map[string]bool{
+	"apk":       true,
+	"bitbucket": true,
+	"composer":  true,
+	"github":    true,
+	"hex":       true,
+	"npm":       true,
+	"pypi":      true,
+}
+
+// ParsePackageURL parses and normalizes a package URL. The type and the
+// qualifier keys are lower-cased, empty qualifiers and path segments are
+// dropped, and type-specific rules are applied: the namespace and name of
+// case-insensitive types such as npm are lower-cased, and underscores in
+// PyPI names are replaced with dashes.
+func ParsePackageURL(s string) (*PackageURL, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("invalid package URL %q: %s", s, fmt.Sprintf(format, args...))
+	}
+
+	scheme, rest, ok := strings.Cut(s, ":")
+	if !ok || !strings.EqualFold(scheme, "pkg") {
+		return nil, invalid("missing pkg scheme")
+	}
+	p := &PackageURL{}
+
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		subpath, err := splitPurlPath(rest[i+1:], true)
+		if err != nil {
+			return nil, invalid("%v", err)
+		}
+		rest, p.Subpath = rest[:i], strings.Join(subpath, "/")
+	}
+	if i := strings.LastIndex(rest, "?"); i >= 0 {
+		for _, pair := range strings.Split(rest[i+1:], "&") {
+			key, value, _ := strings.Cut(pair, "=")
+			value, err := url.PathUnescape(value)
+			if err != nil {
+				return nil, invalid("%v", err)
+			}
+			if key == "" || value == "" {
+				continue
+			}
+			if p.Qualifiers == nil {
+				p.Qualifiers = make(map[string]string)
+			}
+			p.Qualifiers[strings.ToLower(key)] = value
+		}
+		rest = rest[:i]
+	}
+
+	rest = strings.Trim(rest, "/")
+	typ, rest, ok := strings.Cut(rest, "/")
+	if !ok || !validPurlType(typ) {
+		return nil, invalid("missing or invalid type")
+	}
+	p.Type = strings.ToLower(typ)
+
+	// An unescaped @ in the namespace, as in pkg:npm/@angular/core, is not a
+	// version separator.
+	if i := strings.LastIndex(rest, "@"); i > strings.LastIndex(rest, "/") {
+		version, err := url.PathUnescape(rest[i+1:])
+		if err != nil {
+			return nil, invalid("%v", err)
+		}
+		rest, p.Version = rest[:i], version
+	}
+	segments, err := splitPurlPath(rest, false)
+	if err != nil {
+		return nil, invalid("%v", err)
+	}
+	if len(segments) == 0 {
+		return nil, invalid("missing name")
+	}
+	p.Namespace = strings.Join(segments[:len(segments)-1], "/")
+	p.Name = segments[len(segments)-1]
+
+	if lowercasedPurlTypes[p.Type] {
+		p.Namespace, p.Name = strings.ToLower(p.Namespace), strings.ToLower(p.Name)
+	}
+	if p.Type == "pypi" {
+		p.Name = strings.ReplaceAll(p.Name, "_", "-")
+	}
+	return p, nil
+}
+
+// validPurlType reports whether s is a valid package type: ASCII letters,
+// digits, '.', '+' and '-', not starting with a digit.
+func validPurlType(s string) bool {
+	if s == "" || numver.IsDigit(s[0]) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !isAlnum(c) && c != '.' && c != '+' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlnum reports whether c is an ASCII letter or digit.
+func isAlnum(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || numver.IsDigit(c)
+}
+
+// splitPurlPath splits a namespace and name, or a subpath, into unescaped
+// segments, dropping empty ones, and "." and ".." in subpaths.
+func splitPurlPath(s string, subpath bool) ([]string, error) {
+	var segments []string
+	for _, seg := range strings.Split(s, "/") {
+		seg, err := url.PathUnescape(seg)
+		if err != nil {
+			return nil, err
+		}
+		if seg == "" || (subpath && (seg == "." || seg == "..")) {
+			continue
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+// String returns the canonical form of the package URL, with qualifiers
+// sorted by key.
+func (p *PackageURL) String() string {
+	var b strings.Builder
+	b.WriteString("pkg:")
+	b.WriteString(p.Type)
+	b.WriteString("/")
+	if p.Namespace != "" {
+		for _, seg := range strings.Split(p.Namespace, "/") {
+			b.WriteString(escapePurl(seg, ""))
+			b.WriteString("/")
+		}
+	}
+	b.WriteString(escapePurl(p.Name, ""))
+	if p.Version != "" {
+		b.WriteString("@")
+		b.WriteString(escapePurl(p.Version, ""))
+	}
+	keys := make([]string, 0, len(p.Qualifiers))
+	for k := range p.Qualifiers {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for i, k := range keys {
+		if i == 0 {
+			b.WriteString("?")
+		} else {
+			b.WriteString("&")
+		}
+		b.WriteString(k)
+		b.WriteString("=")
+		b.WriteString(escapePurl(p.Qualifiers[k], "/"))
+	}
+	if p.Subpath != "" {
+		b.WriteString("#")
+		b.WriteString(escapePurl(p.Subpath, "/"))
+	}
+	return b.String()
+}
+
+// escapePurl percent-encodes the characters of s other than ASCII letters,
+// digits, '.', '-', '_', '~', ':' and those in keep.
+func escapePurl(s, keep string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isAlnum(c) || strings.IndexByte(".-_~:"+keep, c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// packageKey returns the type, namespace and name of the package URL,
+// lower-cased, as compared when matching products. Docker images without a
+// namespace, such as pkg:docker/postgres, are official images of the
+// library namespace. npm scopes are compared without their leading @, which
+// package URLs are written both with and without, as in
+// pkg:npm/%40angular/core and pkg:npm/angular/core.
+func (p *PackageURL) packageKey() string {
+	namespace := p.Namespace
+	switch p.Type {
+	case "docker":
+		if namespace == "" {
+			namespace = "library"
+		}
+	case "npm":
+		namespace = strings.TrimPrefix(namespace, "@")
+	}
+	return strings.ToLower(p.Type + "/" + namespace + "/" + p.Name)
+}
+
+// PackageURLResolver finds the products owning package URLs, using the purl
+// identifiers of products, and resolves package versions to releases.
+type PackageURLResolver struct {
+	api      API
+	products map[string]string // product names by packageKey
+}
+
+// NewPackageURLResolver retrieves the purl identifiers of products with
+// GetIdentifierDetails and indexes them. Products are later retrieved from
+// the same API.
+func NewPackageURLResolver(ctx context.Context, api API) (*PackageURLResolver, error) {
+	r := &PackageURLResolver{api: api, products: make(map[string]string)}
+	ids, err := api.GetIdentifierDetails(ctx, "purl")
+	if IsNotFound(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range ids.Result {
+		p, err := ParsePackageURL(m.Identifier)
+		if err != nil {
+			continue
+		}
+		if _, exists := r.products[p.packageKey()]; !exists {
+			r.products[p.packageKey()] = m.Product.Name
+		}
+	}
+	return r, nil
+}
+
+// Product returns the name of the product owning a package URL, or "" if
+// none does. Packages are matched on type, namespace and name, ignoring
+// case; the version, qualifiers and subpath are ignored.
+func (r *PackageURLResolver) Product(p *PackageURL) string {
+	return r.products[p.packageKey()]
+}
+
+// Resolve parses a package URL, such as pkg:docker/library/postgres@15.4,
+// finds the product owning it and resolves its version to a release with
+// LookupRelease. If no product owns the package URL, the returned error
+// wraps ErrNoMatchingProduct.
+func (r *PackageURLResolver) Resolve(ctx context.Context, purl string) (product string, release *ProductRelease, err error) {
+	p, err := ParsePackageURL(purl)
+	if err != nil {
+		return "", nil, err
+	}
+	product = r.Product(p)
+	if product == "" {
+		return "", nil, fmt.Errorf("%w: %s", ErrNoMatchingProduct, purl)
+	}
+	if p.Version == "" {
+		return product, nil, fmt.Errorf("%w: package URL %s has no version", ErrNoMatchingRelease, purl)
+	}
+	release, err = LookupRelease(ctx, r.api, product, p.Version)
+	if err != nil {
+		return product, nil, err
+	}
+	return product, release, nil
+}
@@ -0,0 +1,140 @@
+package endoflife
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+)
+
+func TestParsePackageURL(t *testing.T) {
+	tests := []struct {
+		purl      string
+		expected  PackageURL
+		canonical string
+	}{
+		{
+			purl:      "pkg:docker/library/postgres@15.4",
+			expected:  PackageURL{Type: "docker", Namespace: "library", Name: "postgres", Version: "15.4"},
+			canonical: "pkg:docker/library/postgres@15.4",
+		},
+		{
+			purl:      "pkg:npm/%40angular/core@17.1.0",
+			expected:  PackageURL{Type: "npm", Namespace: "@angular", Name: "core", Version: "17.1.0"},
+			canonical: "pkg:npm/%40angular/core@17.1.0",
+		},
+		{
+			purl:      "pkg:npm/@angular/core",
+			expected:  PackageURL{Type: "npm", Namespace: "@angular", Name: "core"},
+			canonical: "pkg:npm/%40angular/core",
+		},
+		{
+			purl:      "PKG:PyPI/Django_Polymorphic@3.1",
+			expected:  PackageURL{Type: "pypi", Name: "django-polymorphic", Version: "3.1"},
+			canonical: "pkg:pypi/django-polymorphic@3.1",
+		},
+		{
+			purl: "pkg:deb/debian/curl@7.50.3-1?Arch=i386&distro=jessie&empty=",
+			expected: PackageURL{Type: "deb", Namespace: "debian", Name: "curl", Version: "7.50.3-1",
+				Qualifiers: map[string]string{"arch": "i386", "distro": "jessie"}},
+			canonical: "pkg:deb/debian/curl@7.50.3-1?arch=i386&distro=jessie",
+		},
+		{
+			purl:      "pkg:golang/github.com/gorilla/context@234fd47e07d1004f0aed9c#./api/../v1/",
+			expected:  PackageURL{Type: "golang", Namespace: "github.com/gorilla", Name: "context", Version: "234fd47e07d1004f0aed9c", Subpath: "api/v1"},
+			canonical: "pkg:golang/github.com/gorilla/context@234fd47e07d1004f0aed9c#api/v1",
+		},
+		{
+			purl:      "pkg:maven/org.apache.xmlgraphics/batik-anim@1.9.1?repository_url=repo.spring.io%2Frelease",
+			expected:  PackageURL{Type: "maven", Namespace: "org.apache.xmlgraphics", Name: "batik-anim", Version: "1.9.1", Qualifiers: map[string]string{"repository_url": "repo.spring.io/release"}},
+			canonical: "pkg:maven/org.apache.xmlgraphics/batik-anim@1.9.1?repository_url=repo.spring.io/release",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			p, err := ParsePackageURL(tt.purl)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Type != tt.expected.Type || p.Namespace != tt.expected.Namespace || p.Name != tt.expected.Name ||
+				p.Version != tt.expected.Version || p.Subpath != tt.expected.Subpath || !maps.Equal(p.Qualifiers, tt.expected.Qualifiers) {
+				t.Errorf("expected %+v, got %+v", tt.expected, *p)
+			}
+			if s := p.String(); s != tt.canonical {
+				t.Errorf("expected canonical form %s, got %s", tt.canonical, s)
+			}
+		})
+	}
+}
+
+func TestParsePackageURL_Invalid(t *testing.T) {
+	for _, purl := range []string{"", "npm/foo", "http://example.com", "pkg:npm", "pkg:1npm/foo", "pkg:npm/", "pkg:npm/foo%zz"} {
+		if _, err := ParsePackageURL(purl); err == nil {
+			t.Errorf("ParsePackageURL(%q): expected error", purl)
+		}
+	}
+}
+
+func testPurlSnapshot() *Snapshot {
+	return NewSnapshot(&FullProductListResponse{
+		Result: []ProductDetails{
+			{
+				Name:        "postgresql",
+				Identifiers: []Identifier{{ID: "pkg:docker/library/postgres", Type: "purl"}},
+				Releases:    []ProductRelease{{Name: "16"}, {Name: "15"}},
+			},
+			{
+				Name:        "angular",
+				Identifiers: []Identifier{{ID: "pkg:npm/%40angular/core", Type: "purl"}},
+				Releases:    []ProductRelease{{Name: "17"}, {Name: "16"}},
+			},
+			{
+				Name:        "ubuntu",
+				Identifiers: []Identifier{{ID: "cpe:/o:canonical:ubuntu_linux", Type: "cpe"}},
+			},
+		},
+	})
+}
+
+func TestPackageURLResolver_Resolve(t *testing.T) {
+	ctx := context.Background()
+	r, err := NewPackageURLResolver(ctx, testPurlSnapshot())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		purl     string
+		product  string
+		expected string
+	}{
+		{purl: "pkg:docker/library/postgres@15.4", product: "postgresql", expected: "15"},
+		{purl: "pkg:docker/postgres@16.1-alpine?arch=amd64", product: "postgresql", expected: "16"},
+		{purl: "pkg:npm/angular/core@17.1.0", product: "angular", expected: "17"},
+		{purl: "pkg:npm/%40Angular/Core@17.1.0", product: "angular", expected: "17"},
+		{purl: "pkg:npm/%40angular/cli@17.1.0", product: "", expected: ""},
+	}
+	for _, tt := range tests {
+		product, release, err := r.Resolve(ctx, tt.purl)
+		if tt.product == "" {
+			if !errors.Is(err, ErrNoMatchingProduct) {
+				t.Errorf("Resolve(%q): expected ErrNoMatchingProduct, got %v", tt.purl, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Resolve(%q): unexpected error: %v", tt.purl, err)
+			continue
+		}
+		if product != tt.product || release.Name != tt.expected {
+			t.Errorf("Resolve(%q): expected %s %s, got %s %s", tt.purl, tt.product, tt.expected, product, release.Name)
+		}
+	}
+
+	for _, purl := range []string{"pkg:docker/postgres", "pkg:docker/postgres@12.1"} {
+		if _, _, err := r.Resolve(ctx, purl); !errors.Is(err, ErrNoMatchingRelease) {
+			t.Errorf("Resolve(%q): expected ErrNoMatchingRelease, got %v", purl, err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package endoflife
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shmokmt/endoflife-go/internal/numver"
+)
+
+// ResolveRelease returns the release of product that an installed version
+// belongs to. The raw version is normalized with NormalizeVersion, so it can
+// be given as reported by tools, for example "python 3.12.4" or "v20.11.1".
+//
+// The release whose name is the longest prefix of the version, compared
+// component by component, is selected: "3.12.4" resolves to "3.12" and
+// "22.04.3" to "22.04". A version that is not a number is matched against
+// release names and codenames instead, so "jammy" resolves to the Ubuntu
+// release codenamed "Jammy Jellyfish".
+//
+// If no release matches, the returned error wraps ErrNoMatchingRelease.
+func ResolveRelease(product *ProductDetails, version string) (*ProductRelease, error) {
+	if normalized := NormalizeVersion(version); normalized != "" {
+		if r := matchReleaseName(product, normalized); r != nil {
+			return r, nil
+		}
+	}
+	if r := matchReleaseLabel(product, version); r != nil {
+		return r, nil
+	}
+	return nil, fmt.Errorf("%w: %s %q", ErrNoMatchingRelease, product.Name, version)
+}
+
+// LookupRelease retrieves a product and resolves an installed version to
+// one of its releases with ResolveRelease.
+func LookupRelease(ctx context.Context, api API, productName, version string) (*ProductRelease, error) {
+	product, err := api.GetProduct(ctx, productName)
+	if err != nil {
+		return nil, err
+	}
+	return ResolveRelease(&product.Result, version)
+}
+
+// matchReleaseName returns the release whose numeric name is the longest
+// component-wise prefix of version, or nil if there is none.
+func matchReleaseName(product *ProductDetails, version string) *ProductRelease {
+	parts, ok := numver.Parse(version)
+	if !ok {
+		return nil
+	}
+
+	var best *ProductRelease
+	bestLen := 0
+	for i := range product.Releases {
+		r := &product.Releases[i]
+		name, ok := numver.Parse(strings.TrimPrefix(strings.ToLower(r.Name), "v"))
+		if !ok || len(name) <= bestLen || !hasVersionPrefix(parts, name) {
+			continue
+		}
+		best, bestLen = r, len(name)
+	}
+	return best
+}
+
+// hasVersionPrefix reports whether prefix matches the leading components
+// of version. Components missing from version are treated as zero, so that
+// "6" matches the release "6.0".
+func hasVersionPrefix(version, prefix []int) bool {
+	for i, p := range prefix {
+		v := 0
+		if i < len(version) {
+			v = version[i]
+		}
+		if v != p {
+			return false
+		}
+	}
+	return true
+}
+
+// matchReleaseLabel returns the release whose name, codename, or first word
+// of the codename equals version, ignoring case, or nil if there is none.
+func matchReleaseLabel(product *ProductDetails, version string) *ProductRelease {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if version == "" {
+		return nil
+	}
+	for i := range product.Releases {
+		r := &product.Releases[i]
+		if strings.ToLower(r.Name) == version {
+			return r
+		}
+		if r.Codename == nil {
+			continue
+		}
+		codename := strings.ToLower(*r.Codename)
+		if codename == version || strings.SplitN(codename, " ", 2)[0] == version {
+			return r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package endoflife
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func testProduct(name string, releases ...ProductRelease) *ProductDetails {
+	return &ProductDetails{Name: name, Releases: releases}
+}
+
+func codename(s string) *string {
+	return &s
+}
+
+func TestResolveRelease(t *testing.T) {
+	products := map[string]*ProductDetails{
+		"python": testProduct("python",
+			ProductRelease{Name: "3.13"}, ProductRelease{Name: "3.12"}, ProductRelease{Name: "3.1"}),
+		"nodejs": testProduct("nodejs",
+			ProductRelease{Name: "22"}, ProductRelease{Name: "20"}, ProductRelease{Name: "2"}),
+		"eclipse-temurin": testProduct("eclipse-temurin",
+			ProductRelease{Name: "21"}, ProductRelease{Name: "17"}),
+		"ubuntu": testProduct("ubuntu",
+			ProductRelease{Name: "24.04", Codename: codename("Noble Numbat")},
+			ProductRelease{Name: "22.04", Codename: codename("Jammy Jellyfish")}),
+		"dotnet": testProduct("dotnet",
+			ProductRelease{Name: "8.0"}, ProductRelease{Name: "6.0"}),
+	}
+
+	tests := []struct {
+		product  string
+		version  string
+		expected string
+	}{
+		{product: "python", version: "3.12.4", expected: "3.12"},
+		{product: "python", version: "python 3.12.4", expected: "3.12"},
+		{product: "python", version: "3.1.5", expected: "3.1"},
+		{product: "python", version: "3.13", expected: "3.13"},
+		{product: "nodejs", version: "node v20.11.1", expected: "20"},
+		{product: "nodejs", version: "22", expected: "22"},
+		{product: "eclipse-temurin", version: "openjdk 17.0.9+9", expected: "17"},
+		{product: "ubuntu", version: "ubuntu 22.04.3 LTS", expected: "22.04"},
+		{product: "ubuntu", version: "jammy", expected: "22.04"},
+		{product: "ubuntu", version: "Noble Numbat", expected: "24.04"},
+		{product: "dotnet", version: "6", expected: "6.0"},
+		{product: "dotnet", version: "8.0.1", expected: "8.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.product+" "+tt.version, func(t *testing.T) {
+			result, err := ResolveRelease(products[tt.product], tt.version)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Name != tt.expected {
+				t.Errorf("ResolveRelease(%s, %q) = %s, want %s", tt.product, tt.version, result.Name, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveRelease_NoMatch(t *testing.T) {
+	product := testProduct("python", ProductRelease{Name: "3.13"}, ProductRelease{Name: "3.12"})
+
+	for _, version := range []string{"2.7.18", "3", "latest", ""} {
+		_, err := ResolveRelease(product, version)
+		if !errors.Is(err, ErrNoMatchingRelease) {
+			t.Errorf("ResolveRelease(%q): expected ErrNoMatchingRelease, got %v", version, err)
+		}
+	}
+}
+
+func TestLookupRelease(t *testing.T) {
+	ctx := context.Background()
+
+	release, err := LookupRelease(ctx, testSnapshot(), "python", "3.12.4")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if release.Name != "3.12" {
+		t.Errorf("expected release 3.12, got %s", release.Name)
+	}
+
+	if _, err := LookupRelease(ctx, testSnapshot(), "nonexistent", "1.0"); !IsNotFound(err) {
+		t.Errorf("expected NotFound error, got %v", err)
+	}
+}
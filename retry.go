@@ -0,0 +1,110 @@
+package endoflife
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of failed requests.
+//
+// Rate-limited (429) and server error (5xx) responses as well as transport
+// errors are retried with exponential backoff and jitter. When the API sends
+// a Retry-After header, its value is used instead of the backoff.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+
+	// InitialBackoff is the base wait time before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the exponential backoff. It does not cap Retry-After.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns a retry policy with sensible defaults.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// delay returns the wait time before the attempt following the given one.
+func (p *RetryPolicy) delay(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return time.Duration(apiErr.RetryAfter) * time.Second
+	}
+	return p.backoff(attempt)
+}
+
+// backoff returns the exponential backoff for the given attempt with
+// "equal jitter": half of the delay is fixed and half is random.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// isRetryable reports whether a failed request may succeed when retried.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrNotModified) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+// sleep waits for d, returning false without waiting if the context
+// deadline would expire first, or as soon as the context is done.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// parseRetryAfter parses a Retry-After header value, given either as
+// delta-seconds or as an HTTP-date, into a number of seconds from now.
+func parseRetryAfter(value string, now time.Time) int {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(seconds, 0)
+	}
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0
+	}
+	seconds := int(t.Sub(now).Round(time.Second) / time.Second)
+	return max(seconds, 0)
+}
@@ -0,0 +1,193 @@
+package endoflife
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
+}
+
+func TestRetry_SucceedsAfterServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	client, server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"schema_version":"1.2.0","total":0,"result":[]}`))
+	})
+	defer server.Close()
+	WithRetry(testRetryPolicy())(client)
+
+	result, err := client.GetIndex(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.SchemaVersion != "1.2.0" {
+		t.Errorf("expected schema_version 1.2.0, got %s", result.SchemaVersion)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestRetry_Exhausted(t *testing.T) {
+	var calls atomic.Int32
+	client, server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+	WithRetry(testRetryPolicy())(client)
+
+	_, err := client.GetIndex(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !IsRateLimited(err) {
+		t.Errorf("expected RateLimited error, got %v", err)
+	}
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("expected RetryError, got %T", err)
+	}
+	if retryErr.Attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", retryErr.Attempts)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestRetry_NotRetryable(t *testing.T) {
+	var calls atomic.Int32
+	client, server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+	WithRetry(testRetryPolicy())(client)
+
+	_, err := client.GetIndex(context.Background())
+	if !IsNotFound(err) {
+		t.Errorf("expected NotFound error, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+}
+
+func TestRetry_TransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithRetry(testRetryPolicy()))
+	_, err := client.GetIndex(context.Background())
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("expected RetryError, got %v", err)
+	}
+	if retryErr.Attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", retryErr.Attempts)
+	}
+}
+
+func TestRetry_RetryAfterExceedsDeadline(t *testing.T) {
+	var calls atomic.Int32
+	client, server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+	WithRetry(testRetryPolicy())(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetIndex(ctx)
+	if !IsRateLimited(err) {
+		t.Errorf("expected RateLimited error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected to give up before the deadline, took %v", elapsed)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{attempt: 1, max: 100 * time.Millisecond},
+		{attempt: 2, max: 200 * time.Millisecond},
+		{attempt: 3, max: 400 * time.Millisecond},
+		{attempt: 10, max: time.Second},
+	}
+
+	for _, tt := range tests {
+		d := p.backoff(tt.attempt)
+		if d < tt.max/2 || d > tt.max {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", tt.attempt, d, tt.max/2, tt.max)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected int
+	}{
+		{name: "empty", value: "", expected: 0},
+		{name: "delta seconds", value: "120", expected: 120},
+		{name: "negative seconds", value: "-5", expected: 0},
+		{name: "http date", value: "Wed, 01 Jan 2025 00:01:30 GMT", expected: 90},
+		{name: "http date in the past", value: "Tue, 31 Dec 2024 23:59:00 GMT", expected: 0},
+		{name: "invalid", value: "soon", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseRetryAfter(tt.value, now)
+			if result != tt.expected {
+				t.Errorf("parseRetryAfter(%q) = %d, want %d", tt.value, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetryError(t *testing.T) {
+	inner := &APIError{StatusCode: 503, Message: "Service Unavailable"}
+	err := &RetryError{Attempts: 4, Err: inner}
+
+	expected := "API error: 503 Service Unavailable (attempts: 4)"
+	if err.Error() != expected {
+		t.Errorf("RetryError.Error() = %s, want %s", err.Error(), expected)
+	}
+	if !errors.Is(err, inner) {
+		t.Error("expected RetryError to unwrap to the last error")
+	}
+}
@@ -0,0 +1,125 @@
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CycloneDX is a CycloneDX JSON document. Fields are kept as decoded, so
+// that the document is written back with the fields this package does not
+// know of.
+type CycloneDX struct {
+	doc map[string]any
+}
+
+// ReadCycloneDX reads a CycloneDX JSON document.
+func ReadCycloneDX(r io.Reader) (*CycloneDX, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode CycloneDX document: %w", err)
+	}
+	if format, _ := doc["bomFormat"].(string); format != "CycloneDX" {
+		return nil, errors.New("not a CycloneDX document: missing bomFormat")
+	}
+	return &CycloneDX{doc: doc}, nil
+}
+
+// Write writes the document as indented JSON.
+func (d *CycloneDX) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d.doc)
+}
+
+// Annotate matches the purl, then the cpe, of each component of the
+// document, including the metadata component and nested components, with
+// the identifiers of endoflife.date products, and resolves the version of
+// matched components. Each matched component is annotated with properties
+// named with PropertyPrefix, replacing those of earlier annotations.
+//
+// The findings of matched components are returned in document order. They
+// are named after the component and fielded after the identifier type that
+// matched; Path is left unset.
+func (d *CycloneDX) Annotate(ctx context.Context, r *Resolver) ([]Finding, error) {
+	var findings []Finding
+	var walk func(components []any) error
+	annotate := func(c map[string]any) error {
+		f, ok, err := d.resolve(ctx, r, c)
+		if err != nil {
+			return err
+		}
+		props := removeProperties(c["properties"])
+		if ok {
+			findings = append(findings, f)
+			for _, p := range findingProperties(f) {
+				props = append(props, map[string]any{"name": p.name, "value": p.value})
+			}
+		}
+		if len(props) > 0 {
+			c["properties"] = props
+		} else {
+			delete(c, "properties")
+		}
+		nested, _ := c["components"].([]any)
+		return walk(nested)
+	}
+	walk = func(components []any) error {
+		for _, item := range components {
+			if c, ok := item.(map[string]any); ok {
+				if err := annotate(c); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if metadata, ok := d.doc["metadata"].(map[string]any); ok {
+		if c, ok := metadata["component"].(map[string]any); ok {
+			if err := annotate(c); err != nil {
+				return nil, err
+			}
+		}
+	}
+	components, _ := d.doc["components"].([]any)
+	if err := walk(components); err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// resolve matches a component with a product and resolves its version. It
+// returns false if the component matches no product.
+func (d *CycloneDX) resolve(ctx context.Context, r *Resolver, c map[string]any) (Finding, bool, error) {
+	var refs []identifierRef
+	for _, field := range []string{"purl", "cpe"} {
+		if id, _ := c[field].(string); id != "" {
+			refs = append(refs, identifierRef{field: field, id: id})
+		}
+	}
+	name, _ := c["name"].(string)
+	version, _ := c["version"].(string)
+	return resolveIdentifiers(ctx, r, name, version, refs)
+}
+
+// removeProperties returns the properties of a component, without those
+// named with PropertyPrefix.
+func removeProperties(v any) []any {
+	props, _ := v.([]any)
+	kept := make([]any, 0, len(props))
+	for _, p := range props {
+		if m, ok := p.(map[string]any); ok {
+			if name, _ := m["name"].(string); strings.HasPrefix(name, PropertyPrefix) {
+				continue
+			}
+		}
+		kept = append(kept, p)
+	}
+	return kept
+}
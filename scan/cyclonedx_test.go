@@ -0,0 +1,144 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testCycloneDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "serialNumber": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "version": 1,
+  "metadata": {
+    "component": {
+      "type": "container",
+      "name": "python",
+      "version": "3.8-slim",
+      "purl": "pkg:docker/python@3.8-slim"
+    }
+  },
+  "components": [
+    {
+      "type": "operating-system",
+      "name": "ubuntu",
+      "cpe": "cpe:2.3:o:canonical:ubuntu_linux:22.04:*:*:*:*:*:*:*"
+    },
+    {
+      "type": "library",
+      "name": "Django",
+      "version": "4.2.7",
+      "purl": "pkg:pypi/django@4.2.7",
+      "properties": [
+        {"name": "endoflife:phase", "value": "stale"},
+        {"name": "aquasecurity:trivy:PkgType", "value": "python-pkg"}
+      ],
+      "components": [
+        {"type": "library", "name": "nested", "purl": "pkg:pypi/django@1.0"}
+      ]
+    },
+    {
+      "type": "library",
+      "name": "left-pad",
+      "version": "1.3.0",
+      "purl": "pkg:npm/left-pad@1.3.0",
+      "hashes": [{"alg": "SHA-1", "content": "5b8a3a7765dfe001261dde915589e782f8c94d1e"}]
+    }
+  ]
+}`
+
+// cdxProperties returns the properties of a decoded CycloneDX component.
+func cdxProperties(c map[string]any) map[string]string {
+	props := make(map[string]string)
+	list, _ := c["properties"].([]any)
+	for _, p := range list {
+		m := p.(map[string]any)
+		props[m["name"].(string)] = m["value"].(string)
+	}
+	return props
+}
+
+func TestCycloneDX_Annotate(t *testing.T) {
+	doc, err := ReadCycloneDX(strings.NewReader(testCycloneDX))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	findings, err := doc.Annotate(context.Background(), testResolver())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []struct {
+		name, product, field, release string
+	}{
+		{name: "python", product: "python", field: "purl", release: "3.8"},
+		{name: "ubuntu", product: "ubuntu", field: "cpe", release: "22.04"},
+		{name: "Django", product: "django", field: "purl", release: "4.2"},
+		{name: "nested", product: "django"},
+	}
+	if len(findings) != len(expected) {
+		t.Fatalf("expected %d findings, got %d", len(expected), len(findings))
+	}
+	for i, f := range findings {
+		e := expected[i]
+		if f.Name != e.name || f.Product != e.product {
+			t.Errorf("expected %s (%s), got %s (%s)", e.name, e.product, f.Name, f.Product)
+		}
+		if e.release == "" {
+			if f.Err == nil {
+				t.Errorf("%s: expected error for unknown release", f.Name)
+			}
+			continue
+		}
+		if f.Err != nil || f.Field != e.field || f.Release.Name != e.release {
+			t.Errorf("%s: unexpected finding: %+v", f.Name, f)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to decode output: %v", err)
+	}
+	if out["serialNumber"] != "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79" {
+		t.Errorf("expected unknown fields to be preserved, got %v", out["serialNumber"])
+	}
+
+	metadata := out["metadata"].(map[string]any)["component"].(map[string]any)
+	props := cdxProperties(metadata)
+	if props["endoflife:release"] != "3.8" || props["endoflife:phase"] != "eol" || props["endoflife:eol"] != "2024-10-07" || props["endoflife:latest"] != "3.8.20" {
+		t.Errorf("unexpected properties: %v", props)
+	}
+
+	components := out["components"].([]any)
+	django := components[1].(map[string]any)
+	props = cdxProperties(django)
+	if props["endoflife:phase"] != "security" {
+		t.Errorf("expected stale property to be replaced, got %v", props)
+	}
+	if props["aquasecurity:trivy:PkgType"] != "python-pkg" || len(props) != 6 {
+		t.Errorf("expected other properties to be kept, got %v", props)
+	}
+	nested := django["components"].([]any)[0].(map[string]any)
+	if props := cdxProperties(nested); props["endoflife:error"] == "" {
+		t.Errorf("expected error property, got %v", props)
+	}
+	leftPad := components[2].(map[string]any)
+	if _, ok := leftPad["properties"]; ok {
+		t.Errorf("expected unmatched component to be left alone, got %v", leftPad["properties"])
+	}
+}
+
+func TestReadCycloneDX_Invalid(t *testing.T) {
+	for _, doc := range []string{`{`, `{"spdxVersion": "SPDX-2.3"}`} {
+		if _, err := ReadCycloneDX(strings.NewReader(doc)); err == nil {
+			t.Errorf("expected error for %s", doc)
+		}
+	}
+}
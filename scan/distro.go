@@ -0,0 +1,89 @@
+package scan
+
+import (
+	"regexp"
+	"strings"
+)
+
+// distro is an operating system tracked on endoflife.date.
+type distro struct {
+	// id is the ID of the distribution in os-release, if any.
+	id string
+
+	// name is the start of the pretty name of the operating system, as in
+	// PRETTY_NAME of os-release or the osImage of Kubernetes nodes.
+	name string
+
+	// product is the endoflife.date product, or "" if untracked.
+	product string
+}
+
+// distros are the operating systems recognized by os-release ID and pretty
+// name. Longer names come before the names they start with, and of the
+// distributions sharing an ID, the one to assume without a name comes first.
+var distros = []distro{
+	{id: "almalinux", name: "AlmaLinux", product: "almalinux"},
+	{id: "alpine", name: "Alpine Linux", product: "alpine"},
+	{id: "amzn", name: "Amazon Linux", product: "amazon-linux"},
+	{id: "bottlerocket", name: "Bottlerocket OS", product: "bottlerocket"},
+	{id: "centos", name: "CentOS Linux", product: "centos"},
+	{id: "centos", name: "CentOS Stream", product: "centos-stream"},
+	{id: "cos", name: "Container-Optimized OS", product: "cos"},
+	{id: "debian", name: "Debian GNU/Linux", product: "debian"},
+	{id: "fedora", name: "Fedora", product: "fedora"},
+	{id: "linuxmint", name: "Linux Mint", product: "linuxmint"},
+	{id: "ol", name: "Oracle Linux", product: "oracle-linux"},
+	{id: "opensuse-leap", name: "openSUSE Leap", product: "opensuse"},
+	{id: "rhcos", name: "Red Hat Enterprise Linux CoreOS"},
+	{id: "rhel", name: "Red Hat Enterprise Linux", product: "rhel"},
+	{id: "rocky", name: "Rocky Linux", product: "rocky-linux"},
+	{id: "sles", name: "SUSE Linux Enterprise Server", product: "sles"},
+	{id: "ubuntu", name: "Ubuntu", product: "ubuntu"},
+	{name: "Windows Server", product: "windows-server"},
+}
+
+// distroByID returns the distribution of an os-release ID, using the pretty
+// name to tell apart distributions sharing an ID, such as CentOS Linux and
+// CentOS Stream.
+func distroByID(id, prettyName string) (distro, bool) {
+	if d, _, ok := distroByName(prettyName); ok && d.id == id {
+		return d, true
+	}
+	for _, d := range distros {
+		if d.id != "" && d.id == id {
+			return d, true
+		}
+	}
+	return distro{}, false
+}
+
+// distroByName returns the distribution whose name a pretty name starts
+// with, ignoring case, and the rest of the pretty name.
+func distroByName(prettyName string) (d distro, rest string, ok bool) {
+	for _, d := range distros {
+		if len(prettyName) >= len(d.name) && strings.EqualFold(prettyName[:len(d.name)], d.name) {
+			return d, prettyName[len(d.name):], true
+		}
+	}
+	return distro{}, "", false
+}
+
+// prettyVersion matches the first version in the rest of a pretty name, as
+// in " 22.04.3 LTS" or " v3.19".
+var prettyVersion = regexp.MustCompile(`^\s+v?(\d+(?:\.\d+)*)\b`)
+
+// parseOSImage maps the pretty name of an operating system, such as
+// "Ubuntu 22.04.3 LTS" or "Debian GNU/Linux 12 (bookworm)", to a product
+// and a version. It returns false for untracked operating systems and
+// names without a version.
+func parseOSImage(s string) (product, version string, ok bool) {
+	d, rest, ok := distroByName(strings.TrimSpace(s))
+	if !ok || d.product == "" {
+		return "", "", false
+	}
+	m := prettyVersion.FindStringSubmatch(rest)
+	if m == nil {
+		return "", "", false
+	}
+	return d.product, m[1], true
+}
@@ -0,0 +1,207 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"strings"
+)
+
+// instruction is a Dockerfile instruction with its continuation lines
+// joined.
+type instruction struct {
+	cmd  string // upper-cased instruction name
+	args []string
+	line int
+}
+
+// ParseDockerfile returns the components pinned by the base images of a
+// Dockerfile, one or two per FROM instruction as described for ParseImage.
+// All stages of a multi-stage build are included, except those based on an
+// earlier stage or on scratch.
+//
+// Variables in image references are substituted with buildArgs, or with
+// the defaults of ARG instructions declared before the first FROM. The
+// --platform flag is ignored.
+//
+// Errors are *ParseErrors. The components of the instructions before a
+// line that cannot be read are returned along with the error.
+func ParseDockerfile(path string, data []byte, buildArgs map[string]string) ([]Component, error) {
+	instructions, err := parseInstructions(path, data)
+
+	args := make(map[string]string)
+	stages := make(map[string]bool)
+	seenFrom := false
+	var components []Component
+	for _, inst := range instructions {
+		switch inst.cmd {
+		case "ARG":
+			// Only ARGs declared before the first FROM apply to FROM
+			// instructions.
+			if seenFrom {
+				continue
+			}
+			for _, arg := range inst.args {
+				name, value, _ := strings.Cut(arg, "=")
+				if v, ok := buildArgs[name]; ok {
+					value = v
+				}
+				args[name] = expandArgs(unquote(value), args)
+			}
+
+		case "FROM":
+			seenFrom = true
+			var image, stage string
+			var rest []string
+			for _, arg := range inst.args {
+				if !strings.HasPrefix(arg, "--") {
+					rest = append(rest, arg)
+				}
+			}
+			if len(rest) == 0 {
+				continue
+			}
+			image = expandArgs(rest[0], args)
+			if len(rest) == 3 && strings.EqualFold(rest[1], "AS") {
+				stage = strings.ToLower(rest[2])
+			}
+
+			if !stages[strings.ToLower(image)] && image != "scratch" {
+				for _, c := range ParseImage(image) {
+					c.Field = "FROM"
+					c.Path = path
+					c.Line = inst.line
+					components = append(components, c)
+				}
+			}
+			if stage != "" {
+				stages[stage] = true
+			}
+		}
+	}
+	return components, err
+}
+
+// parseInstructions splits a Dockerfile into instructions, honoring the
+// escape parser directive, line continuations and comments. If a line
+// cannot be read, the instructions before it are returned with a
+// *ParseError.
+func parseInstructions(path string, data []byte) ([]instruction, error) {
+	escape := `\`
+	var instructions []instruction
+	var current strings.Builder
+	start := 0
+	directives := true
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	n := 1
+	for ; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Parser directives must come first, as comments of the form
+		// "# directive=value".
+		if directives {
+			if key, value, ok := strings.Cut(strings.TrimPrefix(line, "#"), "="); ok && strings.HasPrefix(line, "#") {
+				if strings.EqualFold(strings.TrimSpace(key), "escape") {
+					escape = strings.TrimSpace(value)
+				}
+				continue
+			}
+			directives = false
+		}
+
+		// Comments and empty lines are skipped, even within continuations.
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if current.Len() == 0 {
+			start = n
+		}
+		if body, ok := strings.CutSuffix(line, escape); ok {
+			current.WriteString(body)
+			current.WriteByte(' ')
+			continue
+		}
+		current.WriteString(line)
+
+		if fields := strings.Fields(current.String()); len(fields) > 0 {
+			instructions = append(instructions, instruction{
+				cmd:  strings.ToUpper(fields[0]),
+				args: fields[1:],
+				line: start,
+			})
+		}
+		current.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return instructions, &ParseError{Component: Component{Path: path, Line: n}, Err: err}
+	}
+	return instructions, nil
+}
+
+// expandArgs substitutes $NAME, ${NAME}, ${NAME:-default} and
+// ${NAME:+alternative} in s. Unknown variables expand to "".
+func expandArgs(s string, args map[string]string) string {
+	return os.Expand(s, func(name string) string {
+		if n, def, ok := strings.Cut(name, ":-"); ok {
+			if v := args[n]; v != "" {
+				return v
+			}
+			return expandArgs(def, args)
+		}
+		if n, alt, ok := strings.Cut(name, ":+"); ok {
+			if args[n] != "" {
+				return expandArgs(alt, args)
+			}
+			return ""
+		}
+		return args[name]
+	})
+}
+
+// unquote removes the double or single quotes around s, if any.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// isDockerfile reports whether name is a conventional Dockerfile name, such
+// as Dockerfile, Dockerfile.dev, api.Dockerfile or Containerfile. The base
+// name is case-sensitive, so that dockerfile.go is not a Dockerfile.
+func isDockerfile(name string) bool {
+	for _, base := range []string{"Dockerfile", "Containerfile"} {
+		if name == base || strings.HasPrefix(name, base+".") || strings.HasSuffix(name, "."+base) {
+			return true
+		}
+	}
+	return strings.HasSuffix(strings.ToLower(name), ".dockerfile")
+}
+
+// ScanDockerfiles finds every Dockerfile under root and resolves the
+// components pinned by their base images. Variables are substituted with
+// buildArgs as described for ParseDockerfile. Files that cannot be read
+// are reported as findings with Err set.
+func ScanDockerfiles(ctx context.Context, r *Resolver, root string, buildArgs map[string]string) ([]Finding, error) {
+	paths, err := findFiles(root, isDockerfile)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		c, err := ParseDockerfile(path, data, buildArgs)
+		findings = append(findings, r.ResolveAll(ctx, c)...)
+		if err != nil {
+			findings = append(findings, errorFindings(path, err)...)
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,144 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseDockerfile(t *testing.T) {
+	data := []byte(`# syntax=docker/dockerfile:1
+ARG PYTHON_VERSION=3.8
+ARG BASE="python:${PYTHON_VERSION}-slim-bullseye"
+ARG NODE_VERSION
+
+FROM --platform=$BUILDPLATFORM node:${NODE_VERSION:-16}-alpine AS assets
+RUN npm ci
+
+# The builder stage.
+from golang:1.21 \
+    as builder
+ARG PYTHON_VERSION=3.12
+RUN go build ./...
+
+FROM builder AS test
+FROM scratch AS empty
+
+FROM $BASE
+COPY --from=builder /app /app
+`)
+
+	components, err := ParseDockerfile("Dockerfile", data, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Component{
+		{Product: "nodejs", Version: "16", Name: "node:16-alpine", Field: "FROM", Path: "Dockerfile", Line: 6},
+		{Product: "go", Version: "1.21", Name: "golang:1.21", Field: "FROM", Path: "Dockerfile", Line: 10},
+		{Product: "python", Version: "3.8", Name: "python:3.8-slim-bullseye", Field: "FROM", Path: "Dockerfile", Line: 18},
+		{Product: "debian", Version: "bullseye", Name: "python:3.8-slim-bullseye", Field: "FROM", Path: "Dockerfile", Line: 18},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), components)
+	}
+	for i, c := range components {
+		if c != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], c)
+		}
+	}
+
+	components, err = ParseDockerfile("Dockerfile", data, map[string]string{"PYTHON_VERSION": "3.12", "NODE_VERSION": "22"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if components[0].Version != "22" || components[2].Version != "3.12" {
+		t.Errorf("expected build args to be applied, got %+v", components)
+	}
+}
+
+func TestParseDockerfile_Escape(t *testing.T) {
+	data := []byte("# escape=`\nFROM mcr.microsoft.com/dotnet/sdk:8.0 `\n  AS build\nFROM ubuntu:22.04\n")
+
+	components, err := ParseDockerfile("Dockerfile", data, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(components) != 2 || components[0].Product != "dotnet" || components[1].Line != 4 {
+		t.Errorf("unexpected components: %+v", components)
+	}
+}
+
+func TestParseDockerfile_LongLine(t *testing.T) {
+	data := []byte("FROM python:3.8\nRUN echo " + strings.Repeat("x", 2*1024*1024) + "\nFROM ubuntu:22.04\n")
+
+	components, err := ParseDockerfile("Dockerfile", data, nil)
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Path != "Dockerfile" || pe.Line != 2 {
+		t.Errorf("expected parse error on line 2, got %v", err)
+	}
+	if len(components) != 1 || components[0].Product != "python" {
+		t.Errorf("expected the components before the error, got %+v", components)
+	}
+}
+
+func TestIsDockerfile(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"Dockerfile":         true,
+		"Dockerfile.dev":     true,
+		"api.Dockerfile":     true,
+		"api.dockerfile":     true,
+		"Containerfile":      true,
+		"dockerfile.go":      false,
+		"Dockerfile_test":    false,
+		"docker-compose.yml": false,
+	} {
+		if got := isDockerfile(name); got != expected {
+			t.Errorf("isDockerfile(%q): expected %v, got %v", name, expected, got)
+		}
+	}
+}
+
+func TestScanDockerfiles(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"Dockerfile":        "FROM python:3.8-slim\n",
+		"web/Dockerfile.ci": "FROM node:22-alpine3.20\n",
+		"dockerfile.go":     "package scan\n",
+		"zz/Dockerfile":     "RUN echo " + strings.Repeat("x", 2*1024*1024) + "\n",
+	})
+
+	findings, err := ScanDockerfiles(context.Background(), testResolver(), root, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 4 {
+		t.Fatalf("expected 4 findings, got %d", len(findings))
+	}
+	if f := findings[3]; f.Err == nil || f.Path != filepath.Join(root, "zz", "Dockerfile") || f.Line != 1 {
+		t.Errorf("expected located error for unreadable Dockerfile, got %+v", f)
+	}
+
+	tests := []struct {
+		path    string
+		release string
+		eol     bool
+	}{
+		{path: "Dockerfile", release: "3.8", eol: true},
+		{path: "web/Dockerfile.ci", release: "22"},
+		{path: "web/Dockerfile.ci", release: "3.20"},
+	}
+	for i, tt := range tests {
+		f := findings[i]
+		if f.Err != nil {
+			t.Errorf("unexpected error: %v", f.Err)
+			continue
+		}
+		if f.Path != filepath.Join(root, filepath.FromSlash(tt.path)) {
+			t.Errorf("expected path %s, got %s", tt.path, f.Path)
+		}
+		if f.Release.Name != tt.release || f.IsEOL() != tt.eol {
+			t.Errorf("expected release %s (eol %v), got %s (eol %v)", tt.release, tt.eol, f.Release.Name, f.IsEOL())
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// ParseGoMod returns the Go versions required by a go.mod file: the go
+// directive and, if present, the toolchain directive. Components refer to
+// the "go" product and are named after the module path. Errors are
+// *ParseErrors, joined if there are several.
+func ParseGoMod(path string, data []byte) ([]Component, error) {
+	f, err := modfile.Parse(path, data, nil)
+	if err != nil {
+		return nil, goModError(path, err)
+	}
+
+	var module string
+	if f.Module != nil {
+		module = f.Module.Mod.Path
+	}
+
+	var components []Component
+	if f.Go != nil {
+		components = append(components, Component{
+			Product: "go",
+			Version: f.Go.Version,
+			Name:    module,
+			Field:   "go",
+			Path:    path,
+			Line:    f.Go.Syntax.Start.Line,
+		})
+	}
+	if f.Toolchain != nil {
+		// Toolchain names look like go1.21.3 or go1.21.3+custom. The
+		// special name "default" refers to the go directive.
+		if version, ok := strings.CutPrefix(f.Toolchain.Name, "go"); ok {
+			version, _, _ = strings.Cut(version, "+")
+			components = append(components, Component{
+				Product: "go",
+				Version: version,
+				Name:    module,
+				Field:   "toolchain",
+				Path:    path,
+				Line:    f.Toolchain.Syntax.Start.Line,
+			})
+		}
+	}
+	return components, nil
+}
+
+// goModError converts the errors of modfile.Parse, which locate themselves
+// and name the directive they occurred in, to *ParseErrors.
+func goModError(path string, err error) error {
+	var list modfile.ErrorList
+	if !errors.As(err, &list) {
+		var e *modfile.Error
+		if !errors.As(err, &e) {
+			return &ParseError{Component: Component{Product: "go", Path: path}, Err: err}
+		}
+		list = modfile.ErrorList{*e}
+	}
+	errs := make([]error, 0, len(list))
+	for _, e := range list {
+		errs = append(errs, &ParseError{
+			Component: Component{Product: "go", Field: e.Verb, Path: path, Line: e.Pos.Line},
+			Err:       e.Err,
+		})
+	}
+	return errors.Join(errs...)
+}
+
+// ScanGoMod finds every go.mod file under root, outside of vendor
+// directories, and resolves the Go versions they require. Files that cannot
+// be parsed are reported as findings with Err set.
+func ScanGoMod(ctx context.Context, r *Resolver, root string) ([]Finding, error) {
+	paths, err := findFiles(root, func(name string) bool { return name == "go.mod" })
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		c, err := ParseGoMod(path, data)
+		findings = append(findings, r.ResolveAll(ctx, c)...)
+		if err != nil {
+			findings = append(findings, errorFindings(path, err)...)
+		}
+	}
+	return findings, nil
+}
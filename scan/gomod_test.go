@@ -0,0 +1,100 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGoMod(t *testing.T) {
+	data := []byte(`module example.com/app
+
+go 1.22.1
+
+toolchain go1.23.4+custom
+
+require golang.org/x/mod v0.30.0
+`)
+
+	components, err := ParseGoMod("go.mod", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Component{
+		{Product: "go", Version: "1.22.1", Name: "example.com/app", Field: "go", Path: "go.mod", Line: 3},
+		{Product: "go", Version: "1.23.4", Name: "example.com/app", Field: "toolchain", Path: "go.mod", Line: 5},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %d", len(expected), len(components))
+	}
+	for i, c := range components {
+		if c != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], c)
+		}
+	}
+}
+
+func TestParseGoMod_NoDirectives(t *testing.T) {
+	components, err := ParseGoMod("go.mod", []byte("module example.com/old\n\ntoolchain default\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(components) != 0 {
+		t.Errorf("expected no components, got %+v", components)
+	}
+
+	if _, err := ParseGoMod("go.mod", []byte("module \"unterminated\n")); err == nil {
+		t.Error("expected error for invalid go.mod")
+	}
+
+	_, err = ParseGoMod("go.mod", []byte("module example.com/app\n\ngo one\n"))
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Product != "go" || pe.Line != 3 {
+		t.Errorf("expected parse error on line 3, got %v", err)
+	}
+}
+
+func TestScanGoMod(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"go.mod":          "module example.com/app\n\ngo 1.24.4\n",
+		"tools/go.mod":    "module example.com/tools\n\ngo 1.21\n\ntoolchain go1.22.12\n",
+		"vendor/x/go.mod": "module example.com/x\n\ngo 1.11\n",
+		"zz/go.mod":       "module example.com/zz\n\ngo one\n",
+	})
+
+	findings, err := ScanGoMod(context.Background(), testResolver(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 4 {
+		t.Fatalf("expected 4 findings, got %d", len(findings))
+	}
+	if f := findings[3]; f.Err == nil || f.Path != filepath.Join(root, "zz", "go.mod") || f.Line != 3 {
+		t.Errorf("expected located error for invalid go.mod, got %+v", f)
+	}
+
+	tests := []struct {
+		path    string
+		field   string
+		release string
+		eol     bool
+	}{
+		{path: "go.mod", field: "go", release: "1.24"},
+		{path: "tools/go.mod", field: "go", release: "1.21", eol: true},
+		{path: "tools/go.mod", field: "toolchain", release: "1.22"},
+	}
+	for i, tt := range tests {
+		f := findings[i]
+		if f.Err != nil {
+			t.Errorf("unexpected error: %v", f.Err)
+			continue
+		}
+		if f.Path != filepath.Join(root, filepath.FromSlash(tt.path)) || f.Field != tt.field {
+			t.Errorf("unexpected component: %+v", f.Component)
+		}
+		if f.Release.Name != tt.release || f.IsEOL() != tt.eol {
+			t.Errorf("expected release %s (eol %v), got %s (eol %v)", tt.release, tt.eol, f.Release.Name, f.IsEOL())
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package scan
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shmokmt/endoflife-go"
+)
+
+// MatchIdentifier returns the product whose purl or cpe identifier matches
+// a package URL or CPE name, and the version the identifier carries, if
+// any. Package URLs match as described for PackageURLResolver.Product, and
+// CPE names as described for CPEResolver.Product. It returns "" if no
+// product matches.
+//
+// Identifiers are retrieved with GetIdentifierDetails once for the lifetime
+// of the resolver.
+func (r *Resolver) MatchIdentifier(ctx context.Context, id string) (product, version string, err error) {
+	switch {
+	case strings.HasPrefix(id, "pkg:"):
+		p, err := endoflife.ParsePackageURL(id)
+		if err != nil {
+			return "", "", nil
+		}
+		purls, err := r.purlResolver(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		return purls.Product(p), p.Version, nil
+	case strings.HasPrefix(id, "cpe:"):
+		c, err := endoflife.ParseCPE(id)
+		if err != nil {
+			return "", "", nil
+		}
+		cpes, err := r.cpeResolver(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		return cpes.Product(c), c.ConcreteVersion(), nil
+	default:
+		return "", "", nil
+	}
+}
+
+// purlResolver returns the resolver of package URLs, creating it on first
+// use.
+func (r *Resolver) purlResolver(ctx context.Context) (*endoflife.PackageURLResolver, error) {
+	return r.purls.do(ctx, "", func() (*endoflife.PackageURLResolver, error) {
+		return endoflife.NewPackageURLResolver(ctx, r.api)
+	})
+}
+
+// cpeResolver returns the resolver of CPE names, creating it on first use.
+func (r *Resolver) cpeResolver(ctx context.Context) (*endoflife.CPEResolver, error) {
+	return r.cpes.do(ctx, "", func() (*endoflife.CPEResolver, error) {
+		return endoflife.NewCPEResolver(ctx, r.api)
+	})
+}
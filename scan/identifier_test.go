@@ -0,0 +1,41 @@
+package scan
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResolver_MatchIdentifier(t *testing.T) {
+	ctx := context.Background()
+	r := testResolver()
+
+	tests := []struct {
+		id      string
+		product string
+		version string
+	}{
+		{id: "pkg:docker/node@18.20.4", product: "nodejs", version: "18.20.4"},
+		{id: "pkg:docker/library/python", product: "python"},
+		{id: "pkg:docker/python@3.12-slim?arch=amd64", product: "python", version: "3.12-slim"},
+		{id: "pkg:pypi/django@5.0.3", product: "django", version: "5.0.3"},
+		{id: "pkg:pypi/Django@4.2.7#src", product: "django", version: "4.2.7"},
+		{id: "cpe:2.3:o:canonical:ubuntu_linux:22.04:*:*:*:*:*:*:*", product: "ubuntu", version: "22.04"},
+		{id: "cpe:2.3:a:golang:go:1.22.1", product: "go", version: "1.22.1"},
+		{id: "cpe:/o:canonical:ubuntu_linux:20.04", product: "ubuntu", version: "20.04"},
+		{id: "cpe:2.3:a:nodejs:node.js:*:*:*:*:*:*:*:*", product: "nodejs"},
+		{id: "cpe:2.3:a:python:*:3.12.*", product: "python"},
+		{id: "cpe:2.3:a"},
+		{id: "pkg:npm/left-pad@1.3.0", version: "1.3.0"},
+		{id: "pkg:python"},
+		{id: "not an identifier"},
+	}
+	for _, tt := range tests {
+		product, version, err := r.MatchIdentifier(ctx, tt.id)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if product != tt.product || version != tt.version {
+			t.Errorf("MatchIdentifier(%q): expected %q %q, got %q %q", tt.id, tt.product, tt.version, product, version)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package scan
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/shmokmt/endoflife-go/internal/numver"
+)
+
+// imageProducts maps official container image repositories to
+// endoflife.date products.
+var imageProducts = map[string]string{
+	"almalinux":                        "almalinux",
+	"alpine":                           "alpine",
+	"amazoncorretto":                   "amazon-corretto",
+	"amazonlinux":                      "amazon-linux",
+	"centos":                           "centos",
+	"debian":                           "debian",
+	"eclipse-temurin":                  "eclipse-temurin",
+	"elasticsearch":                    "elasticsearch",
+	"elixir":                           "elixir",
+	"erlang":                           "erlang",
+	"fedora":                           "fedora",
+	"golang":                           "go",
+	"haproxy":                          "haproxy",
+	"httpd":                            "apache-http-server",
+	"mariadb":                          "mariadb",
+	"mcr.microsoft.com/dotnet/aspnet":  "dotnet",
+	"mcr.microsoft.com/dotnet/runtime": "dotnet",
+	"mcr.microsoft.com/dotnet/sdk":     "dotnet",
+	"mongo":                            "mongodb",
+	"mysql":                            "mysql",
+	"nginx":                            "nginx",
+	"node":                             "nodejs",
+	"perl":                             "perl",
+	"php":                              "php",
+	"postgres":                         "postgresql",
+	"python":                           "python",
+	"rabbitmq":                         "rabbitmq",
+	"redis":                            "redis",
+	"rockylinux":                       "rocky-linux",
+	"ruby":                             "ruby",
+	"rust":                             "rust",
+	"tomcat":                           "tomcat",
+	"traefik":                          "traefik",
+	"ubuntu":                           "ubuntu",
+}
+
+// osImages are the images whose tags may start with a codename instead of
+// a version, such as ubuntu:jammy or debian:bookworm-slim.
+var osImages = map[string]bool{
+	"debian": true,
+	"ubuntu": true,
+}
+
+// osCodenames maps the codenames used as tag suffixes of official images to
+// the operating system they refer to.
+var osCodenames = map[string]string{
+	"stretch":  "debian",
+	"buster":   "debian",
+	"bullseye": "debian",
+	"bookworm": "debian",
+	"trixie":   "debian",
+	"bionic":   "ubuntu",
+	"focal":    "ubuntu",
+	"jammy":    "ubuntu",
+	"noble":    "ubuntu",
+}
+
+// alpineSuffix matches tag suffixes such as alpine3.17.
+var alpineSuffix = regexp.MustCompile(`^alpine(\d+\.\d+)$`)
+
+// registryPrefixes are stripped from image repositories to find the name of
+// an official image.
+var registryPrefixes = []string{
+	"docker.io/library/",
+	"index.docker.io/library/",
+	"registry-1.docker.io/library/",
+	"public.ecr.aws/docker/library/",
+	"mirror.gcr.io/library/",
+	"docker.io/",
+	"library/",
+}
+
+// ParseImage returns the components pinned by a container image reference
+// such as python:3.11-alpine3.17: the product of a well-known official image
+// and, if the tag names one, the operating system the image is based on.
+// Components are named after the reference; Path and Line are left unset.
+//
+// References to unknown images, or without a version in their tag, such as
+// node:lts or python:latest, yield no components.
+func ParseImage(ref string) []Component {
+	repo, tag := splitImageRef(ref)
+	product, ok := imageProducts[repo]
+	if !ok || tag == "" {
+		return nil
+	}
+
+	parts := strings.Split(tag, "-")
+	var components []Component
+	add := func(product, version string) {
+		components = append(components, Component{Product: product, Version: version, Name: ref})
+	}
+
+	if (parts[0] != "" && numver.IsDigit(parts[0][0])) || (osImages[repo] && osCodenames[parts[0]] == product) {
+		add(product, parts[0])
+	}
+	for _, part := range parts[1:] {
+		if m := alpineSuffix.FindStringSubmatch(part); m != nil {
+			add("alpine", m[1])
+		} else if distro, ok := osCodenames[part]; ok && distro != product {
+			add(distro, part)
+		}
+	}
+	return components
+}
+
+// splitImageRef splits an image reference into its repository, without a
+// registry prefix for official images, and its tag. Digests are dropped.
+func splitImageRef(ref string) (repo, tag string) {
+	ref, _, _ = strings.Cut(ref, "@")
+	ref = strings.ToLower(ref)
+
+	// A colon after the last slash separates the tag; one before it is part
+	// of a registry host:port.
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref, tag = ref[:i], ref[i+1:]
+	}
+	for _, prefix := range registryPrefixes {
+		if name, ok := strings.CutPrefix(ref, prefix); ok {
+			return name, tag
+		}
+	}
+	return ref, tag
+}
@@ -0,0 +1,48 @@
+package scan
+
+import (
+	"testing"
+)
+
+func TestParseImage(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected []string // product@version
+	}{
+		{ref: "python:3.8-slim", expected: []string{"python@3.8"}},
+		{ref: "node:16-alpine", expected: []string{"nodejs@16"}},
+		{ref: "python:3.11-alpine3.17", expected: []string{"python@3.11", "alpine@3.17"}},
+		{ref: "python:3.12-slim-bookworm", expected: []string{"python@3.12", "debian@bookworm"}},
+		{ref: "eclipse-temurin:17-jdk-jammy", expected: []string{"eclipse-temurin@17", "ubuntu@jammy"}},
+		{ref: "ubuntu:20.04", expected: []string{"ubuntu@20.04"}},
+		{ref: "ubuntu:jammy-20240111", expected: []string{"ubuntu@jammy"}},
+		{ref: "debian:bullseye-slim", expected: []string{"debian@bullseye"}},
+		{ref: "docker.io/library/golang:1.21.5-bookworm", expected: []string{"go@1.21.5", "debian@bookworm"}},
+		{ref: "postgres:16.2@sha256:4aea012537edfad80f98d870a36e6b90b4c09b27be7f4b4759d72db863baeebb", expected: []string{"postgresql@16.2"}},
+		{ref: "mcr.microsoft.com/dotnet/aspnet:8.0-alpine3.19", expected: []string{"dotnet@8.0", "alpine@3.19"}},
+		{ref: "node:lts-alpine3.20", expected: []string{"alpine@3.20"}},
+		{ref: "node:lts"},
+		{ref: "python"},
+		{ref: "python@sha256:4aea012537edfad80f98d870a36e6b90b4c09b27be7f4b4759d72db863baeebb"},
+		{ref: "ghcr.io/example/python:3.12"},
+		{ref: "registry.example.com:5000/app"},
+		{ref: "ubuntu:-x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			components := ParseImage(tt.ref)
+			if len(components) != len(tt.expected) {
+				t.Fatalf("expected %v, got %+v", tt.expected, components)
+			}
+			for i, c := range components {
+				if got := c.Product + "@" + c.Version; got != tt.expected[i] {
+					t.Errorf("expected %s, got %s", tt.expected[i], got)
+				}
+				if c.Name != tt.ref {
+					t.Errorf("expected name %s, got %s", tt.ref, c.Name)
+				}
+			}
+		})
+	}
+}
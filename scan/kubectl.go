@@ -0,0 +1,232 @@
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shmokmt/endoflife-go/internal/numver"
+)
+
+// managedKubernetes maps the markers of the git versions of managed
+// Kubernetes services, as in v1.28.5-eks-5e0fdde, to products.
+var managedKubernetes = map[string]string{
+	"-eks-": "amazon-eks",
+	"-gke.": "google-kubernetes-engine",
+}
+
+// containerRuntimes maps the container runtimes of Kubernetes nodes, as in
+// containerd://1.7.2, to products.
+var containerRuntimes = map[string]string{
+	"containerd": "containerd",
+	"docker":     "docker-engine",
+}
+
+// kubernetesVersion returns the components pinned by the git version of a
+// Kubernetes server or kubelet, such as v1.28.5-eks-5e0fdde: the kubernetes
+// product and, if the version reveals one, the managed service, or the
+// service given as managed.
+func kubernetesVersion(gitVersion, managed string) []Component {
+	v := strings.TrimPrefix(strings.TrimSpace(gitVersion), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	if v == "" || !numver.IsDigit(v[0]) {
+		return nil
+	}
+	components := []Component{{Product: "kubernetes", Version: v, Name: gitVersion}}
+
+	for marker, product := range managedKubernetes {
+		if strings.Contains(gitVersion, marker) {
+			managed = product
+		}
+	}
+	if managed != "" {
+		components = append(components, Component{
+			Product: managed,
+			Version: kubernetesMinor(v),
+			Name:    gitVersion,
+		})
+	}
+	return components
+}
+
+// kubernetesMinor returns the minor version of a Kubernetes version, such
+// as 1.28 of 1.28.5, which managed services support.
+func kubernetesMinor(v string) string {
+	parts := strings.SplitN(v, ".", 3)
+	return strings.Join(parts[:min(len(parts), 2)], ".")
+}
+
+// ParseKubectlVersion returns the Kubernetes versions of the server version
+// in the output of kubectl version -o json, as described for
+// ParseKubectlNodes. The client version is ignored.
+func ParseKubectlVersion(path string, data []byte) ([]Component, error) {
+	var version struct {
+		ServerVersion *struct {
+			GitVersion string `json:"gitVersion"`
+		} `json:"serverVersion"`
+	}
+	if err := json.Unmarshal(data, &version); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if version.ServerVersion == nil {
+		return nil, nil
+	}
+
+	components := kubernetesVersion(version.ServerVersion.GitVersion, "")
+	for i := range components {
+		components[i].Field = "serverVersion"
+		components[i].Path = path
+		components[i].Line = jsonKeyLine(data, "serverVersion", "gitVersion")
+	}
+	return components, nil
+}
+
+// kubectlNode is a node in the output of kubectl get nodes -o json.
+type kubectlNode struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		ProviderID string `json:"providerID"`
+	} `json:"spec"`
+	Status struct {
+		NodeInfo struct {
+			OSImage                 string `json:"osImage"`
+			OperatingSystem         string `json:"operatingSystem"`
+			KernelVersion           string `json:"kernelVersion"`
+			ContainerRuntimeVersion string `json:"containerRuntimeVersion"`
+			KubeletVersion          string `json:"kubeletVersion"`
+		} `json:"nodeInfo"`
+	} `json:"status"`
+}
+
+// ParseKubectlNodes returns the versions of the software of each node in
+// the output of kubectl get nodes -o json, or of a single node:
+//
+//   - the operating system of osImage, such as ubuntu for
+//     "Ubuntu 22.04.3 LTS";
+//   - the linux kernel of kernelVersion, on Linux nodes;
+//   - containerd or docker-engine of containerRuntimeVersion;
+//   - kubernetes of kubeletVersion and, if the version reveals it, as in
+//     v1.28.5-eks-5e0fdde, the managed service: amazon-eks or
+//     google-kubernetes-engine. Nodes of Azure, whose kubelet versions
+//     reveal no service, are taken to be AKS nodes.
+//
+// Components are fielded after the node info field and carry the node as
+// their resource, such as Node/ip-10-0-1-23.
+func ParseKubectlNodes(path string, data []byte) ([]Component, error) {
+	var list struct {
+		kubectlNode
+		Items []kubectlNode `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	nodes := list.Items
+	if list.Kind == "Node" {
+		nodes = []kubectlNode{list.kubectlNode}
+	}
+
+	var components []Component
+	for _, node := range nodes {
+		if node.Kind != "" && node.Kind != "Node" {
+			continue
+		}
+		info := node.Status.NodeInfo
+		add := func(field string, cs ...Component) {
+			for _, c := range cs {
+				c.Field = field
+				c.Resource = "Node/" + node.Metadata.Name
+				c.Path = path
+				components = append(components, c)
+			}
+		}
+
+		if product, version, ok := parseOSImage(info.OSImage); ok {
+			add("osImage", Component{Product: product, Version: version, Name: info.OSImage})
+		}
+		if info.OperatingSystem == "" || info.OperatingSystem == "linux" {
+			v := info.KernelVersion
+			if i := strings.IndexAny(v, "-+"); i >= 0 {
+				v = v[:i]
+			}
+			if v != "" && numver.IsDigit(v[0]) {
+				add("kernelVersion", Component{Product: "linux", Version: v, Name: info.KernelVersion})
+			}
+		}
+		if runtime, v, ok := strings.Cut(info.ContainerRuntimeVersion, "://"); ok {
+			if product, known := containerRuntimes[runtime]; known {
+				add("containerRuntimeVersion", Component{
+					Product: product,
+					Version: strings.TrimPrefix(v, "v"),
+					Name:    info.ContainerRuntimeVersion,
+				})
+			}
+		}
+		managed := ""
+		if strings.HasPrefix(node.Spec.ProviderID, "azure://") {
+			managed = "azure-kubernetes-service"
+		}
+		add("kubeletVersion", kubernetesVersion(info.KubeletVersion, managed)...)
+	}
+	return components, nil
+}
+
+// parseKubectlOutput returns the components of the output of kubectl
+// version or kubectl get nodes, in JSON, telling them apart by content.
+// Other JSON documents yield no components.
+func parseKubectlOutput(path string, data []byte) ([]Component, error) {
+	var probe struct {
+		Kind          string          `json:"kind"`
+		ServerVersion json.RawMessage `json:"serverVersion"`
+		Items         []struct {
+			Kind string `json:"kind"`
+		} `json:"items"`
+	}
+	// Files that are not JSON objects, such as package-lock.json arrays,
+	// are not kubectl output.
+	if json.Unmarshal(data, &probe) != nil {
+		return nil, nil
+	}
+	switch {
+	case probe.ServerVersion != nil:
+		return ParseKubectlVersion(path, data)
+	case probe.Kind == "Node" || (strings.HasSuffix(probe.Kind, "List") && len(probe.Items) > 0 && probe.Items[0].Kind == "Node"):
+		return ParseKubectlNodes(path, data)
+	default:
+		return nil, nil
+	}
+}
+
+// ScanKubectl finds the JSON output of kubectl version and kubectl get
+// nodes under root, such as CI artifacts, and resolves the Kubernetes
+// versions and node software versions they report. Other JSON files are
+// ignored.
+func ScanKubectl(ctx context.Context, r *Resolver, root string) ([]Finding, error) {
+	paths, err := findFiles(root, func(name string) bool {
+		return filepath.Ext(name) == ".json"
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var components []Component
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		c, err := parseKubectlOutput(path, data)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, c...)
+	}
+	return r.ResolveAll(ctx, components), nil
+}
@@ -0,0 +1,187 @@
+package scan
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+const testKubectlVersion = `{
+  "clientVersion": {
+    "major": "1",
+    "minor": "31",
+    "gitVersion": "v1.31.4"
+  },
+  "kustomizeVersion": "v5.4.2",
+  "serverVersion": {
+    "major": "1",
+    "minor": "28+",
+    "gitVersion": "v1.28.15-eks-7f9249a",
+    "platform": "linux/amd64"
+  }
+}`
+
+const testKubectlNodes = `{
+  "apiVersion": "v1",
+  "kind": "List",
+  "items": [
+    {
+      "apiVersion": "v1",
+      "kind": "Node",
+      "metadata": {"name": "aks-pool-0"},
+      "spec": {"providerID": "azure:///subscriptions/0000/resourceGroups/mc/providers/Microsoft.Compute/virtualMachineScaleSets/aks-pool/virtualMachines/0"},
+      "status": {
+        "nodeInfo": {
+          "osImage": "Ubuntu 22.04.5 LTS",
+          "operatingSystem": "linux",
+          "kernelVersion": "5.15.0-1075-azure",
+          "containerRuntimeVersion": "containerd://1.7.23-1",
+          "kubeletVersion": "v1.30.6"
+        }
+      }
+    },
+    {
+      "apiVersion": "v1",
+      "kind": "Node",
+      "metadata": {"name": "win-0"},
+      "status": {
+        "nodeInfo": {
+          "osImage": "Windows Server 2022 Datacenter",
+          "operatingSystem": "windows",
+          "kernelVersion": "10.0.20348.2849",
+          "containerRuntimeVersion": "docker://20.10.24",
+          "kubeletVersion": "v1.27.8-gke.1067004"
+        }
+      }
+    }
+  ]
+}`
+
+func TestParseKubectlVersion(t *testing.T) {
+	components, err := ParseKubectlVersion("version.json", []byte(testKubectlVersion))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Component{
+		{Product: "kubernetes", Version: "1.28.15", Name: "v1.28.15-eks-7f9249a", Field: "serverVersion", Path: "version.json", Line: 11},
+		{Product: "amazon-eks", Version: "1.28", Name: "v1.28.15-eks-7f9249a", Field: "serverVersion", Path: "version.json", Line: 11},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), components)
+	}
+	for i, c := range components {
+		if c != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], c)
+		}
+	}
+
+	components, err = ParseKubectlVersion("version.json", []byte(`{"clientVersion": {"gitVersion": "v1.31.4"}}`))
+	if err != nil || len(components) != 0 {
+		t.Errorf("expected no components without server version, got %+v (%v)", components, err)
+	}
+}
+
+func TestParseKubectlNodes(t *testing.T) {
+	components, err := ParseKubectlNodes("nodes.json", []byte(testKubectlNodes))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []struct {
+		resource, field, product, version string
+	}{
+		{resource: "Node/aks-pool-0", field: "osImage", product: "ubuntu", version: "22.04.5"},
+		{resource: "Node/aks-pool-0", field: "kernelVersion", product: "linux", version: "5.15.0"},
+		{resource: "Node/aks-pool-0", field: "containerRuntimeVersion", product: "containerd", version: "1.7.23-1"},
+		{resource: "Node/aks-pool-0", field: "kubeletVersion", product: "kubernetes", version: "1.30.6"},
+		{resource: "Node/aks-pool-0", field: "kubeletVersion", product: "azure-kubernetes-service", version: "1.30"},
+		{resource: "Node/win-0", field: "osImage", product: "windows-server", version: "2022"},
+		{resource: "Node/win-0", field: "containerRuntimeVersion", product: "docker-engine", version: "20.10.24"},
+		{resource: "Node/win-0", field: "kubeletVersion", product: "kubernetes", version: "1.27.8"},
+		{resource: "Node/win-0", field: "kubeletVersion", product: "google-kubernetes-engine", version: "1.27"},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), components)
+	}
+	for i, c := range components {
+		e := expected[i]
+		if c.Resource != e.resource || c.Field != e.field || c.Product != e.product || c.Version != e.version || c.Path != "nodes.json" {
+			t.Errorf("expected %+v, got %+v", e, c)
+		}
+	}
+
+	components, err = ParseKubectlNodes("node.json", []byte(`{"kind": "Node", "metadata": {"name": "n1"}, "status": {"nodeInfo": {"osImage": "Container-Optimized OS from Google"}}}`))
+	if err != nil || len(components) != 0 {
+		t.Errorf("expected no components for an unversioned OS, got %+v (%v)", components, err)
+	}
+}
+
+func TestParseOSImage(t *testing.T) {
+	tests := []struct {
+		image, product, version string
+	}{
+		{image: "Ubuntu 22.04.3 LTS", product: "ubuntu", version: "22.04.3"},
+		{image: "Debian GNU/Linux 12 (bookworm)", product: "debian", version: "12"},
+		{image: "Alpine Linux v3.19", product: "alpine", version: "3.19"},
+		{image: "Amazon Linux 2", product: "amazon-linux", version: "2"},
+		{image: "Bottlerocket OS 1.19.0 (aws-k8s-1.28)", product: "bottlerocket", version: "1.19.0"},
+		{image: "CentOS Stream 9", product: "centos-stream", version: "9"},
+		{image: "Red Hat Enterprise Linux 9.3 (Plow)", product: "rhel", version: "9.3"},
+		{image: "Red Hat Enterprise Linux CoreOS 414.92.202402051952-0 (Plow)"},
+		{image: "Container-Optimized OS from Google"},
+		{image: "Gentoo Linux"},
+	}
+	for _, tt := range tests {
+		product, version, ok := parseOSImage(tt.image)
+		if ok != (tt.product != "") || product != tt.product || version != tt.version {
+			t.Errorf("parseOSImage(%q): expected %q %q, got %q %q (ok %v)", tt.image, tt.product, tt.version, product, version, ok)
+		}
+	}
+}
+
+func TestScanKubectl(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"artifacts/version.json": testKubectlVersion,
+		"artifacts/nodes.json":   testKubectlNodes,
+		"package.json":           `{"name": "app"}`,
+		"tsconfig.json":          `[1, 2]`,
+	})
+
+	findings, err := ScanKubectl(context.Background(), testResolver(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var kubernetes []Finding
+	for _, f := range findings {
+		if f.Product == "kubernetes" {
+			kubernetes = append(kubernetes, f)
+		}
+	}
+	if len(findings) != 11 || len(kubernetes) != 3 {
+		t.Fatalf("expected 11 findings, 3 of kubernetes, got %+v", findings)
+	}
+
+	// Findings are in path order: nodes.json before version.json.
+	tests := []struct {
+		release string
+		eol     bool
+	}{
+		{release: "1.30"},
+		{release: "1.27"},
+		{release: "1.28", eol: true},
+	}
+	for i, tt := range tests {
+		f := kubernetes[i]
+		if tt.release == "1.27" {
+			if f.Err == nil {
+				t.Errorf("expected error for release missing from fixtures, got %+v", f)
+			}
+			continue
+		}
+		if f.Err != nil || f.Release.Name != tt.release || f.IsEOL() != tt.eol {
+			t.Errorf("expected release %s (eol %v), got %+v", tt.release, tt.eol, f)
+		}
+	}
+	if kubernetes[2].Path != filepath.Join(root, "artifacts", "version.json") {
+		t.Errorf("unexpected path %s", kubernetes[2].Path)
+	}
+}
@@ -0,0 +1,192 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// podSpecPaths are the paths to the pod spec of workload resources by kind.
+var podSpecPaths = map[string][]string{
+	"Pod":                   {"spec"},
+	"PodTemplate":           {"template", "spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// containerFields are the fields of a pod spec listing containers.
+var containerFields = []string{"initContainers", "containers", "ephemeralContainers"}
+
+// ParseKubernetes returns the components pinned by the container images of
+// the workload resources of a Kubernetes manifest, such as Deployments,
+// StatefulSets, DaemonSets, Jobs and CronJobs, as described for ParseImage.
+// A manifest may hold several YAML documents, as rendered by helm template,
+// and List resources, as printed by kubectl get -o yaml.
+//
+// Components are fielded after the container list, such as initContainers,
+// and carry the resource and container they were found in. If a document is
+// not valid YAML, the components of the documents before it are returned
+// along with a *ParseError.
+func ParseKubernetes(path string, data []byte) ([]Component, error) {
+	var components []Component
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return components, yamlError(path, err)
+		}
+		if len(doc.Content) > 0 {
+			components = append(components, parseKubernetesObject(path, doc.Content[0])...)
+		}
+	}
+	return components, nil
+}
+
+// parseKubernetesObject returns the components pinned by the containers of
+// a resource, or of the items of a List.
+func parseKubernetesObject(path string, obj *yaml.Node) []Component {
+	kind := yamlString(yamlPath(obj, "kind"))
+	if strings.HasSuffix(kind, "List") {
+		var components []Component
+		if items := yamlPath(obj, "items"); items != nil && items.Kind == yaml.SequenceNode {
+			for _, item := range items.Content {
+				components = append(components, parseKubernetesObject(path, item)...)
+			}
+		}
+		return components
+	}
+	specPath, ok := podSpecPaths[kind]
+	if !ok {
+		return nil
+	}
+	spec := yamlPath(obj, specPath...)
+	if spec == nil {
+		return nil
+	}
+
+	resource := kind + "/" + yamlString(yamlPath(obj, "metadata", "name"))
+	if namespace := yamlString(yamlPath(obj, "metadata", "namespace")); namespace != "" {
+		resource = namespace + "/" + resource
+	}
+	var components []Component
+	for _, field := range containerFields {
+		containers := yamlPath(spec, field)
+		if containers == nil || containers.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, container := range containers.Content {
+			image := yamlPath(container, "image")
+			for _, c := range ParseImage(yamlString(image)) {
+				c.Field = field
+				c.Path = path
+				c.Line = image.Line
+				c.Resource = resource
+				c.Container = yamlString(yamlPath(container, "name"))
+				components = append(components, c)
+			}
+		}
+	}
+	return components
+}
+
+// yamlPath returns the node at a path of mapping keys, or nil if not found.
+func yamlPath(n *yaml.Node, keys ...string) *yaml.Node {
+	for _, key := range keys {
+		if n == nil || n.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == key {
+				next = n.Content[i+1]
+				break
+			}
+		}
+		n = next
+	}
+	return n
+}
+
+// yamlString returns the value of a scalar node, or "" for other nodes.
+func yamlString(n *yaml.Node) string {
+	if n == nil || n.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return n.Value
+}
+
+// yamlErrorLine matches the line reported by YAML syntax errors.
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+):`)
+
+// yamlError returns a *ParseError for a YAML decoding error of a file, at
+// the line of the error if it reports one.
+func yamlError(path string, err error) *ParseError {
+	c := Component{Path: path}
+	if m := yamlErrorLine.FindStringSubmatch(err.Error()); m != nil {
+		c.Line, _ = strconv.Atoi(m[1])
+	}
+	return &ParseError{Component: c, Err: err}
+}
+
+// isHelmTemplate reports whether path is under the templates directory of
+// a Helm chart, next to its Chart.yaml file.
+func isHelmTemplate(path string) bool {
+	for dir := filepath.Dir(path); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if filepath.Base(dir) != "templates" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "Chart.yaml")); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ScanKubernetes finds every YAML file under root and resolves the
+// container images of the Kubernetes workload resources they define.
+// The templates of Helm charts found under root are skipped: render charts
+// with helm template to scan them. Files that are not valid YAML are
+// reported as findings with Err set.
+func ScanKubernetes(ctx context.Context, r *Resolver, root string) ([]Finding, error) {
+	paths, err := findFiles(root, func(name string) bool {
+		ext := filepath.Ext(name)
+		return ext == ".yaml" || ext == ".yml"
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, path := range paths {
+		if path != root && isHelmTemplate(path) {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		c, err := ParseKubernetes(path, data)
+		findings = append(findings, r.ResolveAll(ctx, c)...)
+		if err != nil {
+			findings = append(findings, errorFindings(path, err)...)
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,137 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseKubernetes(t *testing.T) {
+	data := []byte(`# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: prod
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: migrate
+          image: python:3.8-slim-bullseye
+      containers:
+        - name: app
+          image: docker.io/library/node:18.20.4
+        - name: sidecar
+          image: envoyproxy/envoy:v1.30.0
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: pg-dump
+              image: postgres:16-alpine3.20
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: apps/v1
+    kind: StatefulSet
+    metadata:
+      name: db
+    spec:
+      template:
+        spec:
+          containers:
+            - name: db
+              image: postgres:15.4
+`)
+
+	components, err := ParseKubernetes("manifest.yaml", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Component{
+		{Product: "python", Version: "3.8", Name: "python:3.8-slim-bullseye", Field: "initContainers", Resource: "prod/Deployment/web", Container: "migrate", Path: "manifest.yaml", Line: 12},
+		{Product: "debian", Version: "bullseye", Name: "python:3.8-slim-bullseye", Field: "initContainers", Resource: "prod/Deployment/web", Container: "migrate", Path: "manifest.yaml", Line: 12},
+		{Product: "nodejs", Version: "18.20.4", Name: "docker.io/library/node:18.20.4", Field: "containers", Resource: "prod/Deployment/web", Container: "app", Path: "manifest.yaml", Line: 15},
+		{Product: "postgresql", Version: "16", Name: "postgres:16-alpine3.20", Field: "containers", Resource: "CronJob/backup", Container: "pg-dump", Path: "manifest.yaml", Line: 30},
+		{Product: "alpine", Version: "3.20", Name: "postgres:16-alpine3.20", Field: "containers", Resource: "CronJob/backup", Container: "pg-dump", Path: "manifest.yaml", Line: 30},
+		{Product: "postgresql", Version: "15.4", Name: "postgres:15.4", Field: "containers", Resource: "StatefulSet/db", Container: "db", Path: "manifest.yaml", Line: 49},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), components)
+	}
+	for i, c := range components {
+		if c != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], c)
+		}
+	}
+
+	if _, err := ParseKubernetes("broken.yaml", []byte("kind: [")); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+
+	data = []byte("kind: Pod\nspec:\n  containers:\n    - image: python:3.8\n---\nkind: Pod\nspec: [\n")
+	components, err = ParseKubernetes("broken.yaml", data)
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Path != "broken.yaml" || pe.Line != 7 {
+		t.Errorf("expected parse error on line 7, got %v", err)
+	}
+	if len(components) != 1 || components[0].Version != "3.8" {
+		t.Errorf("expected the components of the first document to be returned along with the error, got %+v", components)
+	}
+}
+
+func TestScanKubernetes(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"deploy/pod.yml": "kind: Pod\nmetadata:\n  name: debug\nspec:\n  containers:\n    - name: shell\n      image: ubuntu:22.04\n",
+		"chart/templates/deployment.yaml": "kind: Deployment\nspec:\n  template:\n    spec:\n      containers:\n" +
+			"        - image: \"{{ .Values.image }}\"\n",
+		"chart/templates/_helpers.yaml": "{{- define \"app.name\" -}}\n{{ .Chart.Name }}\n{{- end }}\n",
+		"chart/Chart.yaml":              "apiVersion: v2\nname: app\n",
+		"chart/values.yaml":             "image: python:3.8\n",
+		"monitoring/rendered.yaml": "kind: ConfigMap\ndata:\n  alert: \"{{ $labels.instance }} is down\"\n---\n" +
+			"kind: Deployment\nmetadata:\n  name: exporter\nspec:\n  template:\n    spec:\n      containers:\n" +
+			"        - name: exporter\n          image: python:3.8\n",
+		"notes/broken.yaml": "kind: Pod\nmetadata:\n  name: debug\nspec:\n  containers:\n    - image: python:3.8\n---\nkind: Pod\nspec: [\n",
+	})
+
+	findings, err := ScanKubernetes(context.Background(), testResolver(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 4 {
+		t.Fatalf("expected 4 findings, got %+v", findings)
+	}
+
+	// Findings are in path order: deploy, monitoring, then notes.
+	if f := findings[1]; f.Err != nil || f.Release.Name != "3.8" || f.Resource != "Deployment/exporter" {
+		t.Errorf("expected rendered manifest with Prometheus templates to be scanned, got %+v", f)
+	}
+	if f := findings[2]; f.Err != nil || f.Release.Name != "3.8" || f.Resource != "Pod/debug" {
+		t.Errorf("expected the Pod before the invalid document to be scanned, got %+v", f)
+	}
+	if f := findings[3]; f.Err == nil || f.Path != filepath.Join(root, "notes", "broken.yaml") || f.Line != 9 {
+		t.Errorf("expected a finding for the invalid document, got %+v", f)
+	}
+	f := findings[0]
+	if f.Err != nil || f.Release.Name != "22.04" || f.Resource != "Pod/debug" || f.Container != "shell" {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if f.Path != filepath.Join(root, "deploy", "pod.yml") || f.Line != 7 {
+		t.Errorf("unexpected source: %s:%d", f.Path, f.Line)
+	}
+}
@@ -0,0 +1,289 @@
+package scan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shmokmt/endoflife-go/internal/numver"
+	"gopkg.in/yaml.v3"
+)
+
+// lambdaLanguages maps the prefixes of Lambda runtime identifiers, as in
+// python3.12, to the products of their languages, longest first.
+var lambdaLanguages = []struct {
+	prefix  string
+	product string
+}{
+	{prefix: "dotnetcore", product: "dotnet"},
+	{prefix: "dotnet", product: "dotnet"},
+	{prefix: "java", product: "amazon-corretto"},
+	{prefix: "nodejs", product: "nodejs"},
+	{prefix: "python", product: "python"},
+	{prefix: "ruby", product: "ruby"},
+}
+
+// lambdaOSReleases maps the suffixes of Lambda runtime identifiers naming
+// their operating system, as in provided.al2023 or java8.al2, to releases
+// of amazon-linux.
+var lambdaOSReleases = map[string]string{
+	".al2":    "2",
+	".al2023": "2023",
+}
+
+// ParseLambdaRuntime returns the components pinned by the identifier of an
+// AWS Lambda runtime, such as python3.12, nodejs20.x, java21, dotnet8,
+// ruby3.3 or provided.al2023:
+//
+//   - aws-lambda, whose releases are named after the runtimes and end when
+//     AWS deprecates them;
+//   - the language of the runtime, such as python 3.12 or nodejs 20. Java
+//     runtimes map to amazon-corretto, which Lambda runs;
+//   - for OS-only runtimes, provided and provided.*, and runtimes naming
+//     their operating system, such as java8.al2, amazon-linux.
+//
+// Components are named after the identifier. It returns nil for an empty
+// identifier.
+func ParseLambdaRuntime(runtime string) []Component {
+	runtime = strings.ToLower(strings.TrimSpace(runtime))
+	if runtime == "" {
+		return nil
+	}
+	components := []Component{{Product: "aws-lambda", Version: runtime, Name: runtime}}
+
+	base := runtime
+	for suffix, release := range lambdaOSReleases {
+		if b, ok := strings.CutSuffix(runtime, suffix); ok {
+			base = b
+			components = append(components, Component{Product: "amazon-linux", Version: release, Name: runtime})
+		}
+	}
+	if base == "provided" {
+		// The first OS-only runtime ran on Amazon Linux 1.
+		if len(components) == 1 {
+			components = append(components, Component{Product: "amazon-linux", Version: "1", Name: runtime})
+		}
+		return components
+	}
+
+	for _, lang := range lambdaLanguages {
+		v, ok := strings.CutPrefix(base, lang.prefix)
+		if !ok {
+			continue
+		}
+		v = strings.TrimSuffix(v, ".x")
+		if v == "" || !numver.IsDigit(v[0]) {
+			break
+		}
+		if lang.product == "dotnet" && !strings.Contains(v, ".") {
+			// .NET releases are named like 8.0.
+			v += ".0"
+		}
+		components = append(components, Component{Product: lang.product, Version: v, Name: runtime})
+		break
+	}
+	return components
+}
+
+// lambdaFunctionTypes are the CloudFormation resource types of Lambda
+// functions.
+var lambdaFunctionTypes = map[string]bool{
+	"AWS::Lambda::Function":     true,
+	"AWS::Serverless::Function": true,
+}
+
+// ParseCloudFormation returns the components pinned by the runtimes of the
+// Lambda functions of a CloudFormation or AWS SAM template, in YAML or
+// JSON, as described for ParseLambdaRuntime. The runtime of the Globals
+// section of SAM templates, which functions without a runtime of their own
+// inherit, is returned once.
+//
+// Components of functions carry the logical ID of the function as their
+// resource. Runtimes given by intrinsic functions, such as !Ref, are
+// skipped.
+func ParseCloudFormation(path string, data []byte) ([]Component, error) {
+	root, err := parseYAMLDocument(path, data)
+	if err != nil {
+		return nil, err
+	}
+	return parseCloudFormation(path, root), nil
+}
+
+// parseCloudFormation returns the runtimes of the Lambda functions of a
+// template.
+func parseCloudFormation(path string, root *yaml.Node) []Component {
+	components := lambdaRuntimeComponents(path, "Globals.Function.Runtime", "", yamlPath(root, "Globals", "Function", "Runtime"))
+
+	resources := yamlPath(root, "Resources")
+	if resources == nil || resources.Kind != yaml.MappingNode {
+		return components
+	}
+	for i := 0; i+1 < len(resources.Content); i += 2 {
+		id, resource := resources.Content[i].Value, resources.Content[i+1]
+		if !lambdaFunctionTypes[yamlString(yamlPath(resource, "Type"))] {
+			continue
+		}
+		components = append(components, lambdaRuntimeComponents(path, "Runtime", id, yamlPath(resource, "Properties", "Runtime"))...)
+	}
+	return components
+}
+
+// ParseServerless returns the components pinned by the runtimes of a
+// Serverless Framework serverless.yml file, as described for
+// ParseLambdaRuntime: the runtime of the provider, which functions without
+// a runtime of their own inherit, and those of functions, which carry the
+// name of the function as their resource. Runtimes given by variables,
+// such as ${self:custom.runtime}, are skipped.
+func ParseServerless(path string, data []byte) ([]Component, error) {
+	root, err := parseYAMLDocument(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	components := lambdaRuntimeComponents(path, "provider.runtime", "", yamlPath(root, "provider", "runtime"))
+	functions := yamlPath(root, "functions")
+	if functions == nil || functions.Kind != yaml.MappingNode {
+		return components, nil
+	}
+	for i := 0; i+1 < len(functions.Content); i += 2 {
+		name, function := functions.Content[i].Value, functions.Content[i+1]
+		components = append(components, lambdaRuntimeComponents(path, "runtime", name, yamlPath(function, "runtime"))...)
+	}
+	return components, nil
+}
+
+// ParseLambdaFunctions returns the components pinned by the runtimes of
+// the functions in the output of aws lambda list-functions, or of a single
+// function as printed by get-function or get-function-configuration, as
+// described for ParseLambdaRuntime. Components carry the function name as
+// their resource. Functions deployed as container images have no runtime
+// and are skipped.
+func ParseLambdaFunctions(path string, data []byte) ([]Component, error) {
+	root, err := parseYAMLDocument(path, data)
+	if err != nil {
+		return nil, err
+	}
+	return parseLambdaFunctions(path, root), nil
+}
+
+// parseLambdaFunctions returns the runtimes of the functions of the output
+// of the AWS CLI.
+func parseLambdaFunctions(path string, root *yaml.Node) []Component {
+	var functions []*yaml.Node
+	switch {
+	case yamlPath(root, "Functions") != nil:
+		if list := yamlPath(root, "Functions"); list.Kind == yaml.SequenceNode {
+			functions = list.Content
+		}
+	case yamlPath(root, "Configuration") != nil:
+		functions = []*yaml.Node{yamlPath(root, "Configuration")}
+	default:
+		functions = []*yaml.Node{root}
+	}
+
+	var components []Component
+	for _, function := range functions {
+		name := yamlString(yamlPath(function, "FunctionName"))
+		components = append(components, lambdaRuntimeComponents(path, "Runtime", name, yamlPath(function, "Runtime"))...)
+	}
+	return components
+}
+
+// lambdaRuntimeComponents returns the components of the runtime identifier
+// of a node, fielded and located. Nodes other than plain strings, such as
+// CloudFormation intrinsic functions, and strings with Serverless
+// Framework variables yield none.
+func lambdaRuntimeComponents(path, field, resource string, n *yaml.Node) []Component {
+	if n == nil || n.Kind != yaml.ScalarNode || n.ShortTag() != "!!str" || strings.Contains(n.Value, "${") {
+		return nil
+	}
+	components := ParseLambdaRuntime(n.Value)
+	for i := range components {
+		components[i].Field = field
+		components[i].Resource = resource
+		components[i].Path = path
+		components[i].Line = n.Line
+	}
+	return components
+}
+
+// parseYAMLDocument returns the root node of the first document of a YAML
+// or JSON file, or nil if it is empty. Errors are *ParseErrors.
+func parseYAMLDocument(path string, data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, yamlError(path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return doc.Content[0], nil
+}
+
+// isServerless reports whether name is the name of a Serverless Framework
+// configuration file.
+func isServerless(name string) bool {
+	return name == "serverless.yml" || name == "serverless.yaml"
+}
+
+// isLambdaCandidate reports whether name is the name of a file that may be
+// a CloudFormation or SAM template or output of the AWS CLI.
+func isLambdaCandidate(name string) bool {
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml", ".json", ".template":
+		return true
+	}
+	return false
+}
+
+// parseLambdaFile returns the runtimes of a file under a directory being
+// scanned, telling templates and AWS CLI output apart by content. Other
+// files, including invalid YAML such as unrendered Helm templates, yield
+// no components; only serverless.yml files are reported as invalid.
+func parseLambdaFile(path string, data []byte) ([]Component, error) {
+	if isServerless(filepath.Base(path)) {
+		return ParseServerless(path, data)
+	}
+	root, err := parseYAMLDocument(path, data)
+	if err != nil || root == nil || root.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	switch {
+	case yamlPath(root, "Resources") != nil:
+		return parseCloudFormation(path, root), nil
+	case yamlPath(root, "Functions") != nil, yamlPath(root, "Configuration") != nil, yamlPath(root, "FunctionName") != nil:
+		return parseLambdaFunctions(path, root), nil
+	default:
+		return nil, nil
+	}
+}
+
+// ScanLambda finds the Serverless Framework serverless.yml files,
+// CloudFormation and SAM templates, and saved aws lambda list-functions
+// output under root, and resolves the runtimes of the Lambda functions
+// they define, so that runtimes are caught before AWS deprecates them and
+// blocks updates. Invalid serverless.yml files are reported as findings
+// with Err set; other YAML and JSON files are ignored.
+func ScanLambda(ctx context.Context, r *Resolver, root string) ([]Finding, error) {
+	paths, err := findFiles(root, func(name string) bool {
+		return isServerless(name) || isLambdaCandidate(name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		c, err := parseLambdaFile(path, data)
+		findings = append(findings, r.ResolveAll(ctx, c)...)
+		if err != nil {
+			findings = append(findings, errorFindings(path, err)...)
+		}
+	}
+	return findings, nil
+}
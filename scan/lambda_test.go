@@ -0,0 +1,225 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseLambdaRuntime(t *testing.T) {
+	tests := []struct {
+		runtime  string
+		expected []string // product@version, after aws-lambda
+	}{
+		{runtime: "python3.12", expected: []string{"python@3.12"}},
+		{runtime: "nodejs18.x", expected: []string{"nodejs@18"}},
+		{runtime: "java11", expected: []string{"amazon-corretto@11"}},
+		{runtime: "java8.al2", expected: []string{"amazon-linux@2", "amazon-corretto@8"}},
+		{runtime: "dotnet6", expected: []string{"dotnet@6.0"}},
+		{runtime: "dotnetcore3.1", expected: []string{"dotnet@3.1"}},
+		{runtime: "ruby3.2", expected: []string{"ruby@3.2"}},
+		{runtime: "provided.al2", expected: []string{"amazon-linux@2"}},
+		{runtime: "provided.al2023", expected: []string{"amazon-linux@2023"}},
+		{runtime: "provided", expected: []string{"amazon-linux@1"}},
+		{runtime: "go1.x"},
+		{runtime: " Python3.9 ", expected: []string{"python@3.9"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.runtime, func(t *testing.T) {
+			components := ParseLambdaRuntime(tt.runtime)
+			if len(components) != len(tt.expected)+1 {
+				t.Fatalf("expected %d components, got %+v", len(tt.expected)+1, components)
+			}
+			runtime := components[0].Name
+			if components[0].Product != "aws-lambda" || components[0].Version != runtime {
+				t.Errorf("expected aws-lambda@%s, got %+v", runtime, components[0])
+			}
+			for i, c := range components[1:] {
+				if got := c.Product + "@" + c.Version; got != tt.expected[i] || c.Name != runtime {
+					t.Errorf("expected %s named %s, got %+v", tt.expected[i], runtime, c)
+				}
+			}
+		})
+	}
+
+	if components := ParseLambdaRuntime(""); components != nil {
+		t.Errorf("expected no components for an empty runtime, got %+v", components)
+	}
+}
+
+func TestParseCloudFormation(t *testing.T) {
+	data := []byte(`AWSTemplateFormatVersion: "2010-09-09"
+Transform: AWS::Serverless-2016-10-31
+Globals:
+  Function:
+    Runtime: python3.9
+Parameters:
+  Runtime:
+    Type: String
+Resources:
+  ApiFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      Handler: app.handler
+  WorkerFunction:
+    Type: AWS::Lambda::Function
+    Properties:
+      Runtime: nodejs16.x
+  ParamFunction:
+    Type: AWS::Lambda::Function
+    Properties:
+      Runtime: !Ref Runtime
+  Bucket:
+    Type: AWS::S3::Bucket
+`)
+	components, err := ParseCloudFormation("template.yaml", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Component{
+		{Product: "aws-lambda", Version: "python3.9", Name: "python3.9", Field: "Globals.Function.Runtime", Path: "template.yaml", Line: 5},
+		{Product: "python", Version: "3.9", Name: "python3.9", Field: "Globals.Function.Runtime", Path: "template.yaml", Line: 5},
+		{Product: "aws-lambda", Version: "nodejs16.x", Name: "nodejs16.x", Field: "Runtime", Resource: "WorkerFunction", Path: "template.yaml", Line: 17},
+		{Product: "nodejs", Version: "16", Name: "nodejs16.x", Field: "Runtime", Resource: "WorkerFunction", Path: "template.yaml", Line: 17},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), components)
+	}
+	for i, c := range components {
+		if c != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], c)
+		}
+	}
+
+	components, err = ParseCloudFormation("template.json", []byte(`{"Resources": {"Fn": {"Type": "AWS::Lambda::Function",
+  "Properties": {"Runtime": {"Ref": "Runtime"}}}}}`))
+	if err != nil || len(components) != 0 {
+		t.Errorf("expected no components for a runtime parameter, got %+v (%v)", components, err)
+	}
+}
+
+func TestParseServerless(t *testing.T) {
+	data := []byte(`service: orders
+provider:
+  name: aws
+  runtime: nodejs18.x
+functions:
+  create:
+    handler: create.handler
+  report:
+    handler: report.handler
+    runtime: python3.8
+  export:
+    handler: export.handler
+    runtime: ${self:custom.runtime}
+`)
+	components, err := ParseServerless("serverless.yml", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Component{
+		{Product: "aws-lambda", Version: "nodejs18.x", Name: "nodejs18.x", Field: "provider.runtime", Path: "serverless.yml", Line: 4},
+		{Product: "nodejs", Version: "18", Name: "nodejs18.x", Field: "provider.runtime", Path: "serverless.yml", Line: 4},
+		{Product: "aws-lambda", Version: "python3.8", Name: "python3.8", Field: "runtime", Resource: "report", Path: "serverless.yml", Line: 10},
+		{Product: "python", Version: "3.8", Name: "python3.8", Field: "runtime", Resource: "report", Path: "serverless.yml", Line: 10},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), components)
+	}
+	for i, c := range components {
+		if c != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], c)
+		}
+	}
+
+	_, err = ParseServerless("serverless.yml", []byte("service: orders\nprovider: [\n"))
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Path != "serverless.yml" || pe.Line != 2 {
+		t.Errorf("expected parse error on line 2, got %v", err)
+	}
+}
+
+func TestParseLambdaFunctions(t *testing.T) {
+	data := []byte(`{
+    "Functions": [
+        {
+            "FunctionName": "orders-api",
+            "Runtime": "provided.al2",
+            "Handler": "bootstrap"
+        },
+        {
+            "FunctionName": "thumbnailer",
+            "PackageType": "Image"
+        }
+    ]
+}`)
+	components, err := ParseLambdaFunctions("functions.json", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Component{
+		{Product: "aws-lambda", Version: "provided.al2", Name: "provided.al2", Field: "Runtime", Resource: "orders-api", Path: "functions.json", Line: 5},
+		{Product: "amazon-linux", Version: "2", Name: "provided.al2", Field: "Runtime", Resource: "orders-api", Path: "functions.json", Line: 5},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), components)
+	}
+	for i, c := range components {
+		if c != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], c)
+		}
+	}
+
+	components, err = ParseLambdaFunctions("function.json", []byte(`{"Configuration": {"FunctionName": "f", "Runtime": "ruby3.2"}}`))
+	if err != nil || len(components) != 2 || components[1].Product != "ruby" || components[1].Resource != "f" {
+		t.Errorf("expected ruby runtime of get-function output, got %+v (%v)", components, err)
+	}
+}
+
+func TestScanLambda(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"api/serverless.yml":             "provider:\n  runtime: python3.8\n",
+		"infra/template.yaml":            "Resources:\n  Fn:\n    Type: AWS::Lambda::Function\n    Properties:\n      Runtime: nodejs20.x\n",
+		"artifacts/functions.json":       `{"Functions": [{"FunctionName": "f", "Runtime": "python3.12"}]}`,
+		"chart/templates/configmap.yaml": "data:\n  {{ .Values.data }}\n",
+		"package.json":                   `{"name": "app"}`,
+		"worker/serverless.yml":          "provider:\n  runtime: [\n",
+	})
+
+	findings, err := ScanLambda(context.Background(), testResolver(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var languages []Finding
+	for _, f := range findings {
+		if f.Product != "aws-lambda" {
+			languages = append(languages, f)
+		}
+	}
+	if len(findings) != 7 || len(languages) != 4 {
+		t.Fatalf("expected 7 findings, 4 of languages and errors, got %+v", findings)
+	}
+
+	// Findings are in path order: api, artifacts, infra, then worker.
+	tests := []struct {
+		product, release string
+		eol              bool
+	}{
+		{product: "python", release: "3.8", eol: true},
+		{product: "python", release: "3.12"},
+		{product: "nodejs", release: "20"},
+	}
+	for i, tt := range tests {
+		f := languages[i]
+		if f.Err != nil || f.Product != tt.product || f.Release.Name != tt.release || f.IsEOL() != tt.eol {
+			t.Errorf("expected %s %s (eol %v), got %+v", tt.product, tt.release, tt.eol, f)
+		}
+	}
+	if languages[0].Path != filepath.Join(root, "api", "serverless.yml") || languages[0].Line != 2 {
+		t.Errorf("unexpected source: %s:%d", languages[0].Path, languages[0].Line)
+	}
+	if f := languages[3]; f.Err == nil || f.Path != filepath.Join(root, "worker", "serverless.yml") || f.Line != 2 {
+		t.Errorf("expected a finding for the invalid serverless.yml, got %+v", f)
+	}
+}
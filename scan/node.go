@@ -0,0 +1,170 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// nodeVersionFiles are the files of version managers pinning a Node.js
+// version.
+var nodeVersionFiles = map[string]bool{
+	".nvmrc":        true,
+	".node-version": true,
+}
+
+// floatingNodeVersions are version manager aliases that do not pin a
+// release.
+var floatingNodeVersions = map[string]bool{
+	"node":    true,
+	"stable":  true,
+	"latest":  true,
+	"current": true,
+	"system":  true,
+	"lts/*":   true,
+	"iojs":    true,
+}
+
+// ParsePackageJSON returns the Node.js versions pinned by a package.json
+// file: the engines.node range and the volta.node version. Components refer
+// to the "nodejs" product and are named after the package. Errors are
+// *ParseErrors; if engines.node is not a valid range, the volta.node
+// version is returned along with the error.
+func ParsePackageJSON(path string, data []byte) ([]Component, error) {
+	var pkg struct {
+		Name    string          `json:"name"`
+		Engines json.RawMessage `json:"engines"`
+		Volta   struct {
+			Node string `json:"node"`
+		} `json:"volta"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, &ParseError{Component: Component{Product: "nodejs", Path: path, Line: jsonErrorLine(data, err)}, Err: err}
+	}
+
+	var components []Component
+	var err error
+
+	// Very old packages declare engines as an array, which is ignored.
+	var engines struct {
+		Node string `json:"node"`
+	}
+	if json.Unmarshal(pkg.Engines, &engines) == nil && strings.TrimSpace(engines.Node) != "" {
+		c := Component{
+			Product: "nodejs",
+			Version: engines.Node,
+			Name:    pkg.Name,
+			Field:   "engines.node",
+			Path:    path,
+			Line:    jsonKeyLine(data, "engines", "node"),
+		}
+		if r, rangeErr := ParseNpmRange(engines.Node); rangeErr != nil {
+			err = &ParseError{Component: c, Err: rangeErr}
+		} else {
+			c.Range = r
+			components = append(components, c)
+		}
+	}
+	if pkg.Volta.Node != "" {
+		components = append(components, Component{
+			Product: "nodejs",
+			Version: pkg.Volta.Node,
+			Name:    pkg.Name,
+			Field:   "volta.node",
+			Path:    path,
+			Line:    jsonKeyLine(data, "volta", "node"),
+		})
+	}
+	return components, err
+}
+
+// ParseNodeVersionFile returns the Node.js version pinned by a version
+// manager file such as .nvmrc or .node-version. An LTS alias such as
+// lts/hydrogen is resolved by codename; floating aliases such as lts/* or
+// node yield no component.
+func ParseNodeVersionFile(path string, data []byte) ([]Component, error) {
+	for i, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		version := strings.ToLower(fields[0])
+		if floatingNodeVersions[version] {
+			return nil, nil
+		}
+		return []Component{{
+			Product: "nodejs",
+			Version: strings.TrimPrefix(version, "lts/"),
+			Field:   filepath.Base(path),
+			Path:    path,
+			Line:    i + 1,
+		}}, nil
+	}
+	return nil, nil
+}
+
+// jsonErrorLine returns the line of a JSON decoding error, or 0 if the
+// error does not locate itself.
+func jsonErrorLine(data []byte, err error) int {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return offsetLine(data, syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		return offsetLine(data, typeErr.Offset)
+	}
+	return 0
+}
+
+// jsonKeyLine returns the line of the last of a sequence of nested object
+// keys in a JSON document, or 0 if not found. The keys are searched in
+// order of appearance, without parsing.
+func jsonKeyLine(data []byte, keys ...string) int {
+	offset := 0
+	for _, key := range keys {
+		i := bytes.Index(data[offset:], []byte(`"`+key+`"`))
+		if i < 0 {
+			return 0
+		}
+		offset += i
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// ScanNode finds every package.json, .nvmrc and .node-version file under
+// root, outside of node_modules, and resolves the Node.js versions they
+// pin. Version ranges resolve to all the release cycles they allow. Files
+// that cannot be parsed and invalid ranges are reported as findings with
+// Err set.
+func ScanNode(ctx context.Context, r *Resolver, root string) ([]Finding, error) {
+	paths, err := findFiles(root, func(name string) bool {
+		return name == "package.json" || nodeVersionFiles[name]
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		parse := ParseNodeVersionFile
+		if filepath.Base(path) == "package.json" {
+			parse = ParsePackageJSON
+		}
+		c, err := parse(path, data)
+		findings = append(findings, r.ResolveAll(ctx, c)...)
+		if err != nil {
+			findings = append(findings, errorFindings(path, err)...)
+		}
+	}
+	return findings, nil
+}
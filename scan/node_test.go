@@ -0,0 +1,146 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePackageJSON(t *testing.T) {
+	data := []byte(`{
+  "name": "web",
+  "dependencies": {
+    "node": "^1.0.0"
+  },
+  "engines": {
+    "npm": ">=9",
+    "node": ">=14"
+  },
+  "volta": {
+    "node": "18.17.0"
+  }
+}
+`)
+
+	components, err := ParsePackageJSON("package.json", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(components) != 2 {
+		t.Fatalf("expected 2 components, got %+v", components)
+	}
+
+	engines := components[0]
+	if engines.Product != "nodejs" || engines.Version != ">=14" || engines.Range == nil ||
+		engines.Name != "web" || engines.Field != "engines.node" || engines.Line != 8 {
+		t.Errorf("unexpected component: %+v", engines)
+	}
+	volta := components[1]
+	if volta.Version != "18.17.0" || volta.Range != nil || volta.Field != "volta.node" || volta.Line != 11 {
+		t.Errorf("unexpected component: %+v", volta)
+	}
+}
+
+func TestParsePackageJSON_Errors(t *testing.T) {
+	components, err := ParsePackageJSON("package.json", []byte(`{"name": "old", "engines": ["node >= 0.8"]}`))
+	if err != nil || len(components) != 0 {
+		t.Errorf("expected array engines to be ignored, got %+v, %v", components, err)
+	}
+
+	components, err = ParsePackageJSON("package.json", []byte("{\n  \"engines\": {\"node\": \"lts\"},\n  \"volta\": {\"node\": \"20.11.0\"}\n}"))
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Field != "engines.node" || pe.Version != "lts" || pe.Line != 2 {
+		t.Errorf("expected parse error for invalid range on line 2, got %v", err)
+	}
+	if len(components) != 1 || components[0].Field != "volta.node" {
+		t.Errorf("expected volta.node to be returned along with the error, got %+v", components)
+	}
+
+	_, err = ParsePackageJSON("package.json", []byte("{\n  \"name\": \"app\",\n  \"engines\": }"))
+	if !errors.As(err, &pe) || pe.Line != 3 {
+		t.Errorf("expected parse error for invalid JSON on line 3, got %v", err)
+	}
+}
+
+func TestParseNodeVersionFile(t *testing.T) {
+	tests := []struct {
+		content string
+		version string
+		line    int
+	}{
+		{content: "v18.17.0\n", version: "v18.17.0", line: 1},
+		{content: "# pinned\n\n20\n", version: "20", line: 3},
+		{content: "lts/hydrogen\n", version: "hydrogen", line: 1},
+		{content: "lts/*\n"},
+		{content: "node"},
+		{content: ""},
+	}
+
+	for _, tt := range tests {
+		components, err := ParseNodeVersionFile(".nvmrc", []byte(tt.content))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tt.version == "" {
+			if len(components) != 0 {
+				t.Errorf("%q: expected no components, got %+v", tt.content, components)
+			}
+			continue
+		}
+		if len(components) != 1 || components[0].Version != tt.version || components[0].Line != tt.line || components[0].Field != ".nvmrc" {
+			t.Errorf("%q: unexpected components: %+v", tt.content, components)
+		}
+	}
+}
+
+func TestScanNode(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"package.json":                   `{"name": "app", "engines": {"node": ">=14 <21"}}`,
+		".nvmrc":                         "lts/hydrogen\n",
+		"api/.node-version":              "22.12.0\n",
+		"node_modules/left/package.json": `{"engines": {"node": ">=0.10"}}`,
+		"broken/package.json":            `{"name": "broken",`,
+		"legacy/package.json":            `{"name": "legacy", "engines": {"node": "lts"}}`,
+	})
+
+	findings, err := ScanNode(context.Background(), testResolver(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 5 {
+		t.Fatalf("expected 5 findings, got %d", len(findings))
+	}
+
+	byPath := make(map[string]Finding)
+	for _, f := range findings {
+		rel, _ := filepath.Rel(root, f.Path)
+		byPath[filepath.ToSlash(rel)] = f
+	}
+
+	if f := byPath["broken/package.json"]; f.Err == nil || f.Line != 1 {
+		t.Errorf("expected error for invalid JSON, got %+v", f)
+	}
+	if f := byPath["legacy/package.json"]; f.Err == nil || f.Field != "engines.node" || f.Version != "lts" || f.Line != 1 {
+		t.Errorf("expected error for invalid range, got %+v", f)
+	}
+	for _, name := range []string{"package.json", ".nvmrc", "api/.node-version"} {
+		if err := byPath[name].Err; err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+	}
+
+	engines := byPath["package.json"]
+	if len(engines.Allowed) != 4 || engines.Allowed[0].Name != "20" || engines.Release.Name != "14" {
+		t.Errorf("expected releases 20 to 14, got %d releases down to %s", len(engines.Allowed), engines.Release.Name)
+	}
+	if !engines.AllowsEOL || !engines.IsEOL() {
+		t.Error("expected range to allow an end of life release")
+	}
+	if f := byPath[".nvmrc"]; f.Release.Name != "18" || f.IsEOL() {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if f := byPath["api/.node-version"]; f.Release.Name != "22" || f.Patch == nil || !f.Patch.UpToDate {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+}
@@ -0,0 +1,152 @@
+package scan
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// osReleaseField is the value of a key of an os-release file and its line.
+type osReleaseField struct {
+	value string
+	line  int
+}
+
+// ParseOSRelease returns the operating system described by an os-release
+// file, such as /etc/os-release, as a single component. The distribution is
+// looked up by ID and, for IDs of untracked derivatives such as pop, by the
+// IDs of ID_LIKE in order. The version is VERSION_ID or, for releases
+// without one such as Debian testing, the codename of VERSION_CODENAME,
+// which resolves against the codenames of releases. Derivatives are looked
+// up by codename first, UBUNTU_CODENAME included, as their versions are
+// their own.
+//
+// The component is named after PRETTY_NAME and fielded after the key its
+// version was read from. Unknown and untracked distributions, such as Red
+// Hat Enterprise Linux CoreOS, yield no component.
+func ParseOSRelease(path string, data []byte) ([]Component, error) {
+	fields := make(map[string]osReleaseField)
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		fields[key] = osReleaseField{value: osReleaseValue(value), line: i + 1}
+	}
+
+	name := cmp.Or(fields["PRETTY_NAME"].value, fields["NAME"].value)
+	keys := []string{"VERSION_ID", "VERSION_CODENAME"}
+	d, ok := distroByID(fields["ID"].value, name)
+	if !ok {
+		for _, like := range strings.Fields(fields["ID_LIKE"].value) {
+			if d, ok = distroByID(like, ""); ok && d.product != "" {
+				keys = []string{"VERSION_CODENAME", "VERSION_ID"}
+				if like == "ubuntu" {
+					keys = append([]string{"UBUNTU_CODENAME"}, keys...)
+				}
+				break
+			}
+		}
+	}
+	if !ok || d.product == "" {
+		return nil, nil
+	}
+
+	for _, key := range keys {
+		if f := fields[key]; f.value != "" {
+			return []Component{{
+				Product: d.product,
+				Version: f.value,
+				Name:    name,
+				Field:   key,
+				Path:    path,
+				Line:    f.line,
+			}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// osReleaseValue returns the value of an os-release assignment, which may
+// be quoted as in a shell: single quotes are taken literally, and double
+// quotes allow the characters ", \, $ and ` to be escaped with a backslash.
+func osReleaseValue(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return unquote(s)
+	}
+	s = s[1 : len(s)-1]
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`", s[i+1]) >= 0 {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// isOSRelease reports whether name is the name of an os-release file, or
+// of one collected from a host, such as web-1.os-release.
+func isOSRelease(name string) bool {
+	return name == "os-release" || strings.HasSuffix(name, ".os-release")
+}
+
+// ScanOSRelease finds every os-release file under root, such as those
+// collected from a fleet of hosts, and resolves the operating systems they
+// describe.
+func ScanOSRelease(ctx context.Context, r *Resolver, root string) ([]Finding, error) {
+	paths, err := findFiles(root, isOSRelease)
+	if err != nil {
+		return nil, err
+	}
+
+	var components []Component
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		c, err := ParseOSRelease(path, data)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, c...)
+	}
+	return r.ResolveAll(ctx, components), nil
+}
+
+// osReleasePaths are the paths of the os-release file of a host relative to
+// its root, in order of precedence.
+var osReleasePaths = []string{"etc/os-release", "usr/lib/os-release"}
+
+// ScanHost resolves the operating system of the host whose file system is
+// at root, "/" for the local host, from its os-release file:
+// /etc/os-release or, failing that, /usr/lib/os-release.
+func ScanHost(ctx context.Context, r *Resolver, root string) ([]Finding, error) {
+	for _, p := range osReleasePaths {
+		path := filepath.Join(root, p)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		components, err := ParseOSRelease(path, data)
+		if err != nil {
+			return nil, err
+		}
+		return r.ResolveAll(ctx, components), nil
+	}
+	return nil, fmt.Errorf("no os-release file found in %s", root)
+}
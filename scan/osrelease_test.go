@@ -0,0 +1,133 @@
+package scan
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseOSRelease(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []Component
+	}{
+		{
+			name: "ubuntu",
+			data: "PRETTY_NAME=\"Ubuntu 22.04.3 LTS\"\nNAME=\"Ubuntu\"\nVERSION_ID=\"22.04\"\nVERSION_CODENAME=jammy\nID=ubuntu\nID_LIKE=debian\n",
+			expected: []Component{
+				{Product: "ubuntu", Version: "22.04", Name: "Ubuntu 22.04.3 LTS", Field: "VERSION_ID", Path: "os-release", Line: 3},
+			},
+		},
+		{
+			name: "codename only",
+			data: "PRETTY_NAME=\"Debian GNU/Linux trixie/sid\"\nNAME=\"Debian GNU/Linux\"\nVERSION_CODENAME=trixie\nID=debian\n",
+			expected: []Component{
+				{Product: "debian", Version: "trixie", Name: "Debian GNU/Linux trixie/sid", Field: "VERSION_CODENAME", Path: "os-release", Line: 3},
+			},
+		},
+		{
+			name: "shared id",
+			data: "NAME=\"CentOS Stream\"\nVERSION=\"9\"\nID=\"centos\"\nID_LIKE=\"rhel fedora\"\nVERSION_ID=\"9\"\n",
+			expected: []Component{
+				{Product: "centos-stream", Version: "9", Name: "CentOS Stream", Field: "VERSION_ID", Path: "os-release", Line: 5},
+			},
+		},
+		{
+			name: "amazon linux",
+			data: "NAME=\"Amazon Linux\"\nVERSION=\"2023\"\nID=\"amzn\"\nID_LIKE=\"fedora\"\nVERSION_ID=\"2023\"\n",
+			expected: []Component{
+				{Product: "amazon-linux", Version: "2023", Name: "Amazon Linux", Field: "VERSION_ID", Path: "os-release", Line: 5},
+			},
+		},
+		{
+			name: "derivative",
+			data: "NAME=\"elementary OS\"\nVERSION_ID=\"7.1\"\nID=elementary\nID_LIKE=\"ubuntu debian\"\nVERSION_CODENAME=horus\nUBUNTU_CODENAME=jammy\n",
+			expected: []Component{
+				{Product: "ubuntu", Version: "jammy", Name: "elementary OS", Field: "UBUNTU_CODENAME", Path: "os-release", Line: 6},
+			},
+		},
+		{
+			name: "quoting",
+			data: "# comment\nNAME='Rocky Linux'\nPRETTY_NAME=\"Rocky \\\"Blue Onyx\\\" \\$9\"\nID=\"rocky\"\nVERSION_ID=\"9.3\"\n",
+			expected: []Component{
+				{Product: "rocky-linux", Version: "9.3", Name: "Rocky \"Blue Onyx\" $9", Field: "VERSION_ID", Path: "os-release", Line: 5},
+			},
+		},
+		{
+			name: "untracked",
+			data: "NAME=\"Red Hat Enterprise Linux CoreOS\"\nID=\"rhcos\"\nID_LIKE=\"rhel fedora\"\nVERSION_ID=\"4.14\"\n",
+		},
+		{
+			name: "unknown",
+			data: "NAME=\"Gentoo\"\nID=gentoo\nVERSION_ID=2.15\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components, err := ParseOSRelease("os-release", []byte(tt.data))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(components) != len(tt.expected) {
+				t.Fatalf("expected %d components, got %+v", len(tt.expected), components)
+			}
+			for i, c := range components {
+				if c != tt.expected[i] {
+					t.Errorf("expected %+v, got %+v", tt.expected[i], c)
+				}
+			}
+		})
+	}
+}
+
+func TestScanOSRelease(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"hosts/web-1.os-release": "ID=ubuntu\nVERSION_ID=\"20.04\"\n",
+		"hosts/db-1/os-release":  "ID=debian\nVERSION_ID=\"10\"\n",
+		"hosts/notes.txt":        "ID=ubuntu\nVERSION_ID=\"18.04\"\n",
+	})
+
+	findings, err := ScanOSRelease(context.Background(), testResolver(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", findings)
+	}
+	tests := []struct {
+		release string
+		eol     bool
+	}{
+		{release: "10", eol: true},
+		{release: "20.04"},
+	}
+	for i, tt := range tests {
+		f := findings[i]
+		if f.Err != nil || f.Release.Name != tt.release || f.IsEOL() != tt.eol {
+			t.Errorf("expected release %s (eol %v), got %+v", tt.release, tt.eol, f)
+		}
+	}
+}
+
+func TestScanHost(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"usr/lib/os-release": "PRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\nID=debian\nVERSION_CODENAME=bookworm\n",
+	})
+
+	findings, err := ScanHost(context.Background(), testResolver(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %+v", findings)
+	}
+	f := findings[0]
+	if f.Err != nil || f.Release.Name != "12" || f.Path != filepath.Join(root, "usr", "lib", "os-release") {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+
+	if _, err := ScanHost(context.Background(), testResolver(), t.TempDir()); err == nil {
+		t.Error("expected error without an os-release file")
+	}
+}
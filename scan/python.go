@@ -0,0 +1,322 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/shmokmt/endoflife-go/internal/numver"
+)
+
+// pythonFrameworks maps the normalized names of tracked PyPI packages to
+// endoflife.date products.
+var pythonFrameworks = map[string]string{
+	"ansible":        "ansible",
+	"ansible-core":   "ansible-core",
+	"apache-airflow": "apache-airflow",
+	"django":         "django",
+	"flask":          "flask",
+	"numpy":          "numpy",
+	"plone":          "plone",
+	"wagtail":        "wagtail",
+}
+
+// pypiNameSeparators matches runs of the characters PEP 503 normalizes to
+// a single dash.
+var pypiNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// normalizePyPIName normalizes a package name as defined by PEP 503.
+func normalizePyPIName(name string) string {
+	return pypiNameSeparators.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+// ParsePythonVersionFile returns the Python versions pinned by a
+// .python-version file, one per line. Versions of other interpreters, such
+// as pypy3.10 or system, are skipped.
+func ParsePythonVersionFile(path string, data []byte) ([]Component, error) {
+	var components []Component
+	for i, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		version := strings.TrimSpace(line)
+		if version == "" || !numver.IsDigit(version[0]) {
+			continue
+		}
+		components = append(components, Component{
+			Product: "python",
+			Version: version,
+			Field:   ".python-version",
+			Path:    path,
+			Line:    i + 1,
+		})
+	}
+	return components, nil
+}
+
+// ParsePyproject returns the Python versions allowed by a pyproject.toml
+// file: the requires-python specifier of the project table and the python
+// constraint of Poetry dependencies, as ranges. Components are named after
+// the project. Errors are *ParseErrors, joined if both constraints are
+// invalid; the valid one is returned along with the error.
+func ParsePyproject(path string, data []byte) ([]Component, error) {
+	var pyproject struct {
+		Project struct {
+			Name           string `toml:"name"`
+			RequiresPython string `toml:"requires-python"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Name         string         `toml:"name"`
+				Dependencies map[string]any `toml:"dependencies"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if err := toml.Unmarshal(data, &pyproject); err != nil {
+		return nil, tomlError(path, "python", data, err)
+	}
+	name := pyproject.Project.Name
+	if name == "" {
+		name = pyproject.Tool.Poetry.Name
+	}
+
+	var components []Component
+	var errs []error
+	if spec := pyproject.Project.RequiresPython; spec != "" {
+		c := Component{
+			Product: "python",
+			Version: spec,
+			Name:    name,
+			Field:   "requires-python",
+			Path:    path,
+			Line:    tomlKeyLine(data, "project", "requires-python"),
+		}
+		if r, err := ParsePEP440Range(spec); err != nil {
+			errs = append(errs, &ParseError{Component: c, Err: err})
+		} else {
+			c.Range = r
+			components = append(components, c)
+		}
+	}
+	if spec, ok := pyproject.Tool.Poetry.Dependencies["python"].(string); ok && spec != "" {
+		c := Component{
+			Product: "python",
+			Version: spec,
+			Name:    name,
+			Field:   "tool.poetry.dependencies.python",
+			Path:    path,
+			Line:    tomlKeyLine(data, "tool.poetry.dependencies", "python"),
+		}
+		// Poetry constraints are npm-like, with commas between comparators.
+		if r, err := ParseNpmRange(strings.ReplaceAll(spec, ",", " ")); err != nil {
+			errs = append(errs, &ParseError{Component: c, Err: err})
+		} else {
+			r.raw = spec
+			c.Range = r
+			components = append(components, c)
+		}
+	}
+	return components, errors.Join(errs...)
+}
+
+// ParseRuntimeTxt returns the Python version pinned by a runtime.txt file
+// as used by Heroku, such as python-3.12.4.
+func ParseRuntimeTxt(path string, data []byte) ([]Component, error) {
+	for i, line := range strings.Split(string(data), "\n") {
+		if version, ok := strings.CutPrefix(strings.TrimSpace(line), "python-"); ok {
+			return []Component{{
+				Product: "python",
+				Version: version,
+				Field:   "runtime.txt",
+				Path:    path,
+				Line:    i + 1,
+			}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// ParsePipfile returns the Python version required by a Pipfile:
+// python_full_version, or python_version, of the requires table.
+func ParsePipfile(path string, data []byte) ([]Component, error) {
+	var pipfile struct {
+		Requires struct {
+			PythonVersion     string `toml:"python_version"`
+			PythonFullVersion string `toml:"python_full_version"`
+		} `toml:"requires"`
+	}
+	if err := toml.Unmarshal(data, &pipfile); err != nil {
+		return nil, tomlError(path, "python", data, err)
+	}
+
+	key, version := "python_full_version", pipfile.Requires.PythonFullVersion
+	if version == "" {
+		key, version = "python_version", pipfile.Requires.PythonVersion
+	}
+	if version == "" {
+		return nil, nil
+	}
+	return []Component{{
+		Product: "python",
+		Version: version,
+		Field:   key,
+		Path:    path,
+		Line:    tomlKeyLine(data, "requires", key),
+	}}, nil
+}
+
+// requirement matches a requirement pinned with == or ===, such as
+// "Django[argon2]==4.2.7 ; python_version >= '3.8'".
+var requirement = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*===?\s*([^\s;,\\]+)`)
+
+// ParseRequirements returns the pinned versions of tracked frameworks, such
+// as Django or Flask, in a pip requirements file. Requirements that are not
+// pinned to a single version are skipped.
+func ParseRequirements(path string, data []byte) ([]Component, error) {
+	var components []Component
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		m := requirement.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		product, ok := pythonFrameworks[normalizePyPIName(m[1])]
+		if !ok {
+			continue
+		}
+		components = append(components, Component{
+			Product: product,
+			Version: m[2],
+			Name:    m[1],
+			Path:    path,
+			Line:    n,
+		})
+	}
+	return components, scanner.Err()
+}
+
+// ParsePoetryLock returns the locked versions of tracked frameworks in a
+// poetry.lock file.
+func ParsePoetryLock(path string, data []byte) ([]Component, error) {
+	var lock struct {
+		Packages []struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, tomlError(path, "", data, err)
+	}
+
+	var components []Component
+	for _, pkg := range lock.Packages {
+		product, ok := pythonFrameworks[normalizePyPIName(pkg.Name)]
+		if !ok {
+			continue
+		}
+		components = append(components, Component{
+			Product: product,
+			Version: pkg.Version,
+			Name:    pkg.Name,
+			Path:    path,
+			Line:    lineOf(data, fmt.Sprintf("name = %q", pkg.Name)),
+		})
+	}
+	return components, nil
+}
+
+// tomlError returns the *ParseError of a TOML decoding error in a file
+// pinning versions of product, located if the error is a syntax error.
+func tomlError(path, product string, data []byte, err error) *ParseError {
+	c := Component{Product: product, Path: path}
+	var syntaxErr toml.ParseError
+	if errors.As(err, &syntaxErr) {
+		c.Line = syntaxErr.Position.Line
+	}
+	return &ParseError{Component: c, Err: err}
+}
+
+// tomlKeyLine returns the line of a key in a table of a TOML document, or 0
+// if not found. The document is searched line by line, without parsing, so
+// only keys written within their table's header are found.
+func tomlKeyLine(data []byte, table, key string) int {
+	current := ""
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			current = strings.Trim(line, "[] ")
+			continue
+		}
+		k, _, ok := strings.Cut(line, "=")
+		if ok && current == table && strings.Trim(strings.TrimSpace(k), `"'`) == key {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// lineOf returns the line of the first occurrence of s in data, or 0 if
+// not found.
+func lineOf(data []byte, s string) int {
+	i := bytes.Index(data, []byte(s))
+	if i < 0 {
+		return 0
+	}
+	return bytes.Count(data[:i], []byte("\n")) + 1
+}
+
+// pythonParsers are the parsers of Python project files by name.
+var pythonParsers = map[string]func(path string, data []byte) ([]Component, error){
+	".python-version": ParsePythonVersionFile,
+	"pyproject.toml":  ParsePyproject,
+	"runtime.txt":     ParseRuntimeTxt,
+	"Pipfile":         ParsePipfile,
+	"poetry.lock":     ParsePoetryLock,
+}
+
+// pythonParser returns the parser of a Python project file, or nil.
+func pythonParser(name string) func(path string, data []byte) ([]Component, error) {
+	if strings.HasPrefix(name, "requirements") && strings.HasSuffix(name, ".txt") {
+		return ParseRequirements
+	}
+	return pythonParsers[name]
+}
+
+// ScanPython finds the Python project files under root and resolves the
+// Python versions and tracked framework versions they pin: .python-version,
+// pyproject.toml, runtime.txt, Pipfile, requirements*.txt and poetry.lock.
+// Files that cannot be parsed and invalid constraints are reported as
+// findings with Err set.
+func ScanPython(ctx context.Context, r *Resolver, root string) ([]Finding, error) {
+	paths, err := findFiles(root, func(name string) bool { return pythonParser(name) != nil })
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, path := range paths {
+		parse := pythonParser(filepath.Base(path))
+		if parse == nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		c, err := parse(path, data)
+		findings = append(findings, r.ResolveAll(ctx, c)...)
+		if err != nil {
+			findings = append(findings, errorFindings(path, err)...)
+		}
+	}
+	return findings, nil
+}
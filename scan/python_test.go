@@ -0,0 +1,193 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParsePythonVersionFile(t *testing.T) {
+	components, err := ParsePythonVersionFile(".python-version", []byte("3.12.4\nsystem\npypy3.10-7.3.12\n3.11 # fallback\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(components) != 2 {
+		t.Fatalf("expected 2 components, got %+v", components)
+	}
+	if c := components[1]; c.Product != "python" || c.Version != "3.11" || c.Line != 4 {
+		t.Errorf("unexpected component: %+v", c)
+	}
+}
+
+func TestParsePyproject(t *testing.T) {
+	data := []byte(`[project]
+name = "app"
+requires-python = ">=3.8, !=3.9.*"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+django = "^4.2"
+`)
+
+	components, err := ParsePyproject("pyproject.toml", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(components) != 2 {
+		t.Fatalf("expected 2 components, got %+v", components)
+	}
+
+	project := components[0]
+	if project.Version != ">=3.8, !=3.9.*" || project.Name != "app" || project.Field != "requires-python" || project.Line != 3 {
+		t.Errorf("unexpected component: %+v", project)
+	}
+	if !project.Range.AllowsRelease("3.8") || project.Range.AllowsRelease("3.9") || !project.Range.AllowsRelease("3.13") {
+		t.Errorf("unexpected range: %s", project.Range)
+	}
+
+	poetry := components[1]
+	if poetry.Version != "^3.11" || poetry.Range.String() != "^3.11" || poetry.Line != 6 {
+		t.Errorf("unexpected component: %+v", poetry)
+	}
+	if poetry.Range.AllowsRelease("3.10") || !poetry.Range.AllowsRelease("3.12") || poetry.Range.AllowsRelease("4.0") {
+		t.Errorf("unexpected range: %s", poetry.Range)
+	}
+
+	if _, err := ParsePyproject("pyproject.toml", []byte("[project]\nrequires-python = \"3.8\"\n")); err == nil {
+		t.Error("expected error for specifier without operator")
+	}
+
+	data = []byte("[project]\nrequires-python = \"3.8\"\n\n[tool.poetry.dependencies]\npython = \"^3.11\"\n")
+	components, err = ParsePyproject("pyproject.toml", data)
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Field != "requires-python" || pe.Line != 2 {
+		t.Errorf("expected parse error for requires-python on line 2, got %v", err)
+	}
+	if len(components) != 1 || components[0].Field != "tool.poetry.dependencies.python" {
+		t.Errorf("expected the Poetry constraint to be returned along with the error, got %+v", components)
+	}
+
+	_, err = ParsePyproject("pyproject.toml", []byte("[project]\nname = \"app\"\nrequires-python = \n"))
+	if !errors.As(err, &pe) || pe.Product != "python" || pe.Line != 3 {
+		t.Errorf("expected parse error for invalid TOML on line 3, got %v", err)
+	}
+}
+
+func TestParseRuntimeTxtAndPipfile(t *testing.T) {
+	components, err := ParseRuntimeTxt("runtime.txt", []byte("python-3.11.4\n"))
+	if err != nil || len(components) != 1 || components[0].Version != "3.11.4" {
+		t.Errorf("unexpected result: %+v, %v", components, err)
+	}
+
+	pipfile := []byte(`[packages]
+django = "*"
+
+[requires]
+python_version = "3.8"
+`)
+	components, err = ParsePipfile("Pipfile", pipfile)
+	if err != nil || len(components) != 1 {
+		t.Fatalf("unexpected result: %+v, %v", components, err)
+	}
+	if c := components[0]; c.Version != "3.8" || c.Field != "python_version" || c.Line != 5 {
+		t.Errorf("unexpected component: %+v", c)
+	}
+}
+
+func TestParseRequirements(t *testing.T) {
+	data := []byte(`# web
+Django[argon2]==4.2.7 ; python_version >= "3.8" \
+    --hash=sha256:abc
+flask>=2.0
+requests==2.31.0
+-r base.txt
+Ansible_Core===2.16.3  # pinned
+`)
+
+	components, err := ParseRequirements("requirements.txt", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Component{
+		{Product: "django", Version: "4.2.7", Name: "Django", Path: "requirements.txt", Line: 2},
+		{Product: "ansible-core", Version: "2.16.3", Name: "Ansible_Core", Path: "requirements.txt", Line: 7},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), components)
+	}
+	for i, c := range components {
+		if c != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], c)
+		}
+	}
+}
+
+func TestParsePoetryLock(t *testing.T) {
+	data := []byte(`[[package]]
+name = "asgiref"
+version = "3.8.1"
+
+[[package]]
+name = "django"
+version = "5.0.3"
+`)
+
+	components, err := ParsePoetryLock("poetry.lock", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(components) != 1 || components[0].Product != "django" || components[0].Version != "5.0.3" || components[0].Line != 6 {
+		t.Errorf("unexpected components: %+v", components)
+	}
+}
+
+func TestScanPython(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		".python-version":        "3.8.10\n",
+		"pyproject.toml":         "[project]\nrequires-python = \">=3.11\"\n",
+		"requirements-dev.txt":   "django==4.2.7\n",
+		"web/poetry.lock":        "[[package]]\nname = \"django\"\nversion = \"3.2.25\"\n",
+		".venv/requirements.txt": "django==1.11\n",
+		"notes.txt":              "django==1.11\n",
+		"legacy/pyproject.toml":  "[project]\nrequires-python = \"3.6\"\n",
+		"legacy/Pipfile":         "[requires\n",
+	})
+
+	findings, err := ScanPython(context.Background(), testResolver(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 6 {
+		t.Fatalf("expected 6 findings, got %d", len(findings))
+	}
+
+	tests := map[string]struct {
+		release string
+		eol     bool
+	}{
+		".python-version":      {release: "3.8", eol: true},
+		"pyproject.toml":       {release: "3.11"},
+		"requirements-dev.txt": {release: "4.2"},
+		"web/poetry.lock":      {release: "3.2", eol: true},
+	}
+	for _, f := range findings {
+		rel, _ := filepath.Rel(root, f.Path)
+		rel = filepath.ToSlash(rel)
+		if strings.HasPrefix(rel, "legacy/") {
+			if f.Err == nil || f.Product != "python" || f.Line == 0 {
+				t.Errorf("%s: expected located error, got %+v", rel, f)
+			}
+			continue
+		}
+		tt := tests[rel]
+		if f.Err != nil {
+			t.Errorf("%s: unexpected error: %v", rel, f.Err)
+			continue
+		}
+		if f.Release.Name != tt.release || f.IsEOL() != tt.eol {
+			t.Errorf("%s: expected release %s (eol %v), got %s (eol %v)", rel, tt.release, tt.eol, f.Release.Name, f.IsEOL())
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shmokmt/endoflife-go"
+)
+
+// Resolver resolves components to releases, retrieving each product once.
+// It is safe for concurrent use.
+type Resolver struct {
+	api endoflife.API
+
+	// At is the date lifecycle statuses are evaluated at. The zero value
+	// means the current time.
+	At time.Time
+
+	products lookups[*endoflife.ProductDetails]
+	list     lookups[*endoflife.ProductListResponse]
+	purls    lookups[*endoflife.PackageURLResolver]
+	cpes     lookups[*endoflife.CPEResolver]
+}
+
+// lookups remembers the results of lookups by key. Concurrent lookups of
+// the same key share a single call, while lookups of different keys run in
+// parallel. The zero value is ready to use.
+type lookups[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*lookup[T]
+}
+
+// lookup is a lookup in progress or done.
+type lookup[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+
+	// canceled reports whether the context of the call was canceled, in
+	// which case the result is forgotten and waiters retry.
+	canceled bool
+}
+
+// do returns the result of fn for key, calling it unless a call for key is
+// done or in progress. Results, including errors, are remembered, unless
+// ctx is canceled during the call.
+func (l *lookups[T]) do(ctx context.Context, key string, fn func() (T, error)) (T, error) {
+	for {
+		l.mu.Lock()
+		if c, ok := l.calls[key]; ok {
+			l.mu.Unlock()
+			select {
+			case <-c.done:
+			case <-ctx.Done():
+				var zero T
+				return zero, ctx.Err()
+			}
+			if c.canceled {
+				continue
+			}
+			return c.val, c.err
+		}
+		if l.calls == nil {
+			l.calls = make(map[string]*lookup[T])
+		}
+		c := &lookup[T]{done: make(chan struct{})}
+		l.calls[key] = c
+		l.mu.Unlock()
+
+		c.val, c.err = fn()
+		if ctx.Err() != nil {
+			c.canceled = true
+			l.mu.Lock()
+			delete(l.calls, key)
+			l.mu.Unlock()
+		}
+		close(c.done)
+		return c.val, c.err
+	}
+}
+
+// NewResolver creates a Resolver that retrieves products from api.
+func NewResolver(api endoflife.API) *Resolver {
+	return &Resolver{api: api}
+}
+
+// Product retrieves a product by name. Results, including errors, are
+// remembered for the lifetime of the resolver. Concurrent calls for the
+// same product share a single request, and different products are
+// retrieved in parallel.
+func (r *Resolver) Product(ctx context.Context, name string) (*endoflife.ProductDetails, error) {
+	return r.products.do(ctx, name, func() (*endoflife.ProductDetails, error) {
+		resp, err := r.api.GetProduct(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		return &resp.Result, nil
+	})
+}
+
+// ProductName returns the name of the product that name refers to by name
+// or alias, ignoring case, such as "nodejs" for "node". Products are listed
+// once for the lifetime of the resolver. If no product matches, it returns
+// an error wrapping endoflife.ErrNoMatchingProduct.
+func (r *Resolver) ProductName(ctx context.Context, name string) (string, error) {
+	list, err := r.list.do(ctx, "", func() (*endoflife.ProductListResponse, error) {
+		return r.api.GetProducts(ctx)
+	})
+	if err != nil {
+		return "", err
+	}
+	if product, ok := findProductName(list, name); ok {
+		return product, nil
+	}
+	return "", fmt.Errorf("%w: %s", endoflife.ErrNoMatchingProduct, name)
+}
+
+// findProductName returns the name of the listed product named or aliased
+// name.
+func findProductName(list *endoflife.ProductListResponse, name string) (string, bool) {
+	for _, p := range list.Result {
+		if strings.EqualFold(p.Name, name) {
+			return p.Name, true
+		}
+	}
+	for _, p := range list.Result {
+		for _, alias := range p.Aliases {
+			if strings.EqualFold(alias, name) {
+				return p.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Resolve resolves a component to a release of its product and evaluates
+// its lifecycle status and patch level. A range is resolved to all the
+// releases it allows. Lookup errors are reported in the finding's Err
+// field.
+func (r *Resolver) Resolve(ctx context.Context, c Component) Finding {
+	f := Finding{Component: c}
+
+	product, err := r.Product(ctx, c.Product)
+	if err != nil {
+		f.Err = err
+		return f
+	}
+	if c.Range != nil {
+		r.resolveRange(&f, product)
+		return f
+	}
+	release, err := endoflife.ResolveRelease(product, c.Version)
+	if err != nil {
+		f.Err = err
+		return f
+	}
+
+	at := r.now()
+	f.Release = release
+	f.Lifecycle = release.StatusAt(at)
+	if release.Latest != nil {
+		f.Patch, _ = endoflife.CheckPatchLevel(c.Version, release, at)
+	}
+	return f
+}
+
+// resolveRange resolves a range to the releases it allows.
+func (r *Resolver) resolveRange(f *Finding, product *endoflife.ProductDetails) {
+	at := r.now()
+	for i := range product.Releases {
+		release := &product.Releases[i]
+		if !f.Range.AllowsRelease(release.Name) {
+			continue
+		}
+		f.Allowed = append(f.Allowed, release)
+		if release.StatusAt(at).Phase == endoflife.PhaseEOL {
+			f.AllowsEOL = true
+		}
+	}
+	if len(f.Allowed) == 0 {
+		f.Err = fmt.Errorf("%w: %s %q", endoflife.ErrNoMatchingRelease, product.Name, f.Version)
+		return
+	}
+	f.Release = f.Allowed[len(f.Allowed)-1]
+	f.Lifecycle = f.Release.StatusAt(at)
+}
+
+// ResolveAll resolves each component in order.
+func (r *Resolver) ResolveAll(ctx context.Context, components []Component) []Finding {
+	findings := make([]Finding, 0, len(components))
+	for _, c := range components {
+		findings = append(findings, r.Resolve(ctx, c))
+	}
+	return findings
+}
+
+func (r *Resolver) now() time.Time {
+	if r.At.IsZero() {
+		return time.Now()
+	}
+	return r.At
+}
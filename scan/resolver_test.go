@@ -0,0 +1,152 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shmokmt/endoflife-go"
+)
+
+// countingAPI is a decorator that counts product lookups.
+type countingAPI struct {
+	endoflife.API
+	productCalls int
+}
+
+func (a *countingAPI) GetProduct(ctx context.Context, productName string) (*endoflife.ProductResponse, error) {
+	a.productCalls++
+	return a.API.GetProduct(ctx, productName)
+}
+
+// blockingAPI is a decorator whose lookups of python wait for a lookup of
+// go to start.
+type blockingAPI struct {
+	endoflife.API
+	goStarted chan struct{}
+	calls     atomic.Int32
+}
+
+func (a *blockingAPI) GetProduct(ctx context.Context, productName string) (*endoflife.ProductResponse, error) {
+	a.calls.Add(1)
+	switch productName {
+	case "go":
+		close(a.goStarted)
+	case "python":
+		select {
+		case <-a.goStarted:
+		case <-time.After(5 * time.Second):
+			return nil, errors.New("go was not looked up concurrently")
+		}
+	}
+	return a.API.GetProduct(ctx, productName)
+}
+
+func TestResolver_ProductConcurrent(t *testing.T) {
+	ctx := context.Background()
+	api := &blockingAPI{API: testResolver().api, goStarted: make(chan struct{})}
+	r := NewResolver(api)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i, name := range []string{"python", "python", "go", "python"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = r.Product(ctx, name)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if n := api.calls.Load(); n != 2 {
+		t.Errorf("expected 2 product lookups, got %d", n)
+	}
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	ctx := context.Background()
+	base := testResolver()
+	api := &countingAPI{API: base.api}
+	r := NewResolver(api)
+	r.At = base.At
+
+	findings := r.ResolveAll(ctx, []Component{
+		{Product: "go", Version: "1.21.5"},
+		{Product: "go", Version: "1.23.10"},
+		{Product: "go", Version: "2.0"},
+		{Product: "nonexistent", Version: "1.0"},
+		{Product: "nonexistent", Version: "2.0"},
+	})
+	if api.productCalls != 2 {
+		t.Errorf("expected 2 product lookups, got %d", api.productCalls)
+	}
+
+	if f := findings[0]; f.Err != nil || f.Release.Name != "1.21" || !f.IsEOL() {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if f := findings[0]; f.Patch == nil || f.Patch.PatchesBehind != 8 {
+		t.Errorf("unexpected patch status: %+v", f.Patch)
+	}
+	if f := findings[1]; f.Err != nil || f.IsEOL() || f.Lifecycle.Phase != endoflife.PhaseActive || !f.Patch.UpToDate {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if f := findings[2]; !errors.Is(f.Err, endoflife.ErrNoMatchingRelease) || f.IsEOL() {
+		t.Errorf("expected ErrNoMatchingRelease, got %v", f.Err)
+	}
+	if f := findings[4]; !endoflife.IsNotFound(f.Err) {
+		t.Errorf("expected not found error, got %v", f.Err)
+	}
+}
+
+func TestResolver_ResolveRange(t *testing.T) {
+	ctx := context.Background()
+	r := testResolver()
+
+	newer, _ := ParseNpmRange(">=20")
+	f := r.Resolve(ctx, Component{Product: "nodejs", Version: ">=20", Range: newer})
+	if f.Err != nil {
+		t.Fatalf("unexpected error: %v", f.Err)
+	}
+	if len(f.Allowed) != 3 || f.Release.Name != "20" || f.AllowsEOL || f.Patch != nil {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+
+	none, _ := ParseNpmRange(">=30")
+	f = r.Resolve(ctx, Component{Product: "nodejs", Version: ">=30", Range: none})
+	if !errors.Is(f.Err, endoflife.ErrNoMatchingRelease) {
+		t.Errorf("expected ErrNoMatchingRelease, got %v", f.Err)
+	}
+}
+
+func TestResolver_ProductName(t *testing.T) {
+	ctx := context.Background()
+	r := testResolver()
+
+	tests := map[string]string{
+		"python":  "python",
+		"CPython": "python",
+		"node":    "nodejs",
+		"golang":  "go",
+	}
+	for name, expected := range tests {
+		got, err := r.ProductName(ctx, name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != expected {
+			t.Errorf("ProductName(%q): expected %s, got %s", name, expected, got)
+		}
+	}
+
+	if _, err := r.ProductName(ctx, "awscli"); !errors.Is(err, endoflife.ErrNoMatchingProduct) {
+		t.Errorf("expected ErrNoMatchingProduct, got %v", err)
+	}
+}
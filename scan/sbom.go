@@ -0,0 +1,121 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// SBOM is a software bill of materials whose components can be annotated
+// with end-of-life data.
+type SBOM interface {
+	// Annotate matches the components of the document with products,
+	// resolves their versions and annotates them. It returns the findings
+	// of matched components.
+	Annotate(ctx context.Context, r *Resolver) ([]Finding, error)
+
+	// Write writes the document in the format it was read in.
+	Write(w io.Writer) error
+}
+
+var (
+	_ SBOM = (*CycloneDX)(nil)
+	_ SBOM = (*SPDX)(nil)
+)
+
+// ReadSBOM reads a CycloneDX JSON, SPDX JSON or SPDX tag-value document,
+// detecting its format.
+func ReadSBOM(r io.Reader) (SBOM, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return ReadSPDX(bytes.NewReader(data))
+	}
+
+	var probe struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	switch {
+	case probe.BOMFormat != "":
+		return ReadCycloneDX(bytes.NewReader(data))
+	case probe.SPDXVersion != "":
+		return ReadSPDX(bytes.NewReader(data))
+	default:
+		return nil, errors.New("unknown SBOM format: expected CycloneDX or SPDX")
+	}
+}
+
+// PropertyPrefix is the prefix of the names of the properties added to
+// annotated SBOM components.
+const PropertyPrefix = "endoflife:"
+
+// property is a name-value pair annotating an SBOM component.
+type property struct {
+	name, value string
+}
+
+// findingProperties returns the properties annotating the component of a
+// finding: its product, release, lifecycle phase, end of life (a date, or
+// true or false if unknown) and latest version, or the error that occurred
+// while resolving it.
+func findingProperties(f Finding) []property {
+	props := []property{{PropertyPrefix + "product", f.Product}}
+	if f.Err != nil {
+		return append(props, property{PropertyPrefix + "error", f.Err.Error()})
+	}
+
+	props = append(props,
+		property{PropertyPrefix + "release", f.Release.Name},
+		property{PropertyPrefix + "phase", string(f.Lifecycle.Phase)},
+	)
+	eol := strconv.FormatBool(f.Release.IsEOL)
+	if f.Release.EOLFrom != nil && !f.Release.EOLFrom.IsZero() {
+		eol = f.Release.EOLFrom.String()
+	}
+	props = append(props, property{PropertyPrefix + "eol", eol})
+	if f.Release.Latest != nil {
+		props = append(props, property{PropertyPrefix + "latest", f.Release.Latest.Name})
+	}
+	return props
+}
+
+// identifierRef is a package URL or CPE name of an SBOM component.
+type identifierRef struct {
+	field string // "purl" or "cpe"
+	id    string
+}
+
+// resolveIdentifiers matches the identifiers of an SBOM component, in
+// order, with a product and resolves the component's version, or the
+// version of the matching identifier if the component has none. It returns
+// false if no identifier matches a product.
+func resolveIdentifiers(ctx context.Context, r *Resolver, name, version string, refs []identifierRef) (Finding, bool, error) {
+	for _, ref := range refs {
+		product, idVersion, err := r.MatchIdentifier(ctx, ref.id)
+		if err != nil {
+			return Finding{}, false, err
+		}
+		if product == "" {
+			continue
+		}
+		if version == "" {
+			version = idVersion
+		}
+		return r.Resolve(ctx, Component{
+			Product: product,
+			Version: version,
+			Name:    name,
+			Field:   ref.field,
+		}), true, nil
+	}
+	return Finding{}, false, nil
+}
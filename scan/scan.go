@@ -0,0 +1,180 @@
+// Package scan finds software components pinned in project files, such as
+// the go directive of a go.mod file, and reports their support status on
+// endoflife.date.
+//
+// Each scanner comes as a pair of functions: a Parse function that extracts
+// components from the contents of a single file, and a Scan function that
+// walks a directory tree and resolves every component found with a
+// Resolver.
+package scan
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/shmokmt/endoflife-go"
+)
+
+// Component is a product version found in a file.
+type Component struct {
+	// Product is the endoflife.date product name, for example "go".
+	Product string `json:"product"`
+
+	// Version is the version as written in the file.
+	Version string `json:"version"`
+
+	// Range is the range of versions allowed by Version, if it is a version
+	// constraint such as ">=14" rather than a single version.
+	Range *Range `json:"-"`
+
+	// Name identifies what the component belongs to, such as a module path,
+	// if known.
+	Name string `json:"name,omitempty"`
+
+	// Field is the directive or key the version was read from, for example
+	// "toolchain".
+	Field string `json:"field,omitempty"`
+
+	// Resource is the resource the component was found in: a Kubernetes
+	// resource as Kind/name, prefixed with the namespace if set, for
+	// example prod/Deployment/web, a Terraform resource as type.name, or a
+	// Lambda function by logical ID or name.
+	Resource string `json:"resource,omitempty"`
+
+	// Container is the name of the container the component was found in.
+	Container string `json:"container,omitempty"`
+
+	// Path is the path of the file the component was found in.
+	Path string `json:"path"`
+
+	// Line is the 1-based line number of the component, or 0 if unknown.
+	Line int `json:"line,omitempty"`
+}
+
+// Finding is a component resolved to a release of its product.
+type Finding struct {
+	Component
+
+	// Release is the release the version belongs to, or nil if Err is set.
+	// For a range, it is the oldest release the range allows.
+	Release *endoflife.ProductRelease
+
+	// Lifecycle is the lifecycle status of Release at the resolver's date.
+	Lifecycle endoflife.LifecycleStatus
+
+	// Patch is the patch level of the version, or nil if the latest version
+	// of the release is unknown, the version is not numeric, or it is a
+	// range.
+	Patch *endoflife.PatchStatus
+
+	// Allowed are the releases a range allows, from the newest to the
+	// oldest. It is nil for single versions.
+	Allowed []*endoflife.ProductRelease
+
+	// AllowsEOL reports whether a range allows a release that is end of life
+	// at the resolver's date.
+	AllowsEOL bool
+
+	// Err is the error that occurred while resolving the component.
+	Err error
+}
+
+// IsEOL reports whether the component's release is end of life.
+func (f *Finding) IsEOL() bool {
+	return f.Err == nil && f.Lifecycle.Phase == endoflife.PhaseEOL
+}
+
+// ParseError is an error in a file, such as invalid syntax or an invalid
+// version constraint. Component locates the error and describes what was
+// being parsed, as far as known.
+type ParseError struct {
+	Component
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	s := e.Path
+	if e.Line > 0 {
+		s += ":" + strconv.Itoa(e.Line)
+	}
+	if e.Field != "" {
+		s += ": " + e.Field
+	}
+	return s + ": " + e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// errorFindings returns findings reporting the errors of parsing a file, so
+// that a scan reports a file it cannot parse and goes on. err is a
+// *ParseError, several joined with errors.Join, or any other error, which
+// is reported for the file as a whole.
+func errorFindings(path string, err error) []Finding {
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	findings := make([]Finding, 0, len(errs))
+	for _, err := range errs {
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			findings = append(findings, Finding{Component: pe.Component, Err: pe.Err})
+			continue
+		}
+		findings = append(findings, Finding{Component: Component{Path: path}, Err: err})
+	}
+	return findings
+}
+
+// offsetLine returns the 1-based line of a byte offset in data.
+func offsetLine(data []byte, offset int64) int {
+	offset = min(max(offset, 0), int64(len(data)))
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// skipDirs are directories that are not descended into while walking.
+var skipDirs = map[string]bool{
+	".git":         true,
+	".terraform":   true,
+	".tox":         true,
+	".venv":        true,
+	"node_modules": true,
+	"vendor":       true,
+	"venv":         true,
+}
+
+// findFiles returns the files under root whose base name satisfies match.
+// If root is a file, it is returned as is.
+func findFiles(root string, match func(name string) bool) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{root}, nil
+	}
+
+	var paths []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() && match(d.Name()) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths, err
+}
@@ -0,0 +1,97 @@
+package scan
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/shmokmt/endoflife-go"
+	"github.com/shmokmt/endoflife-go/endoflifetest"
+)
+
+// testResolver returns a resolver over the endoflifetest fixtures that
+// evaluates statuses at 2025-01-15.
+func testResolver() *Resolver {
+	products := endoflifetest.Fixtures()
+	r := NewResolver(endoflife.NewSnapshot(&endoflife.FullProductListResponse{
+		Total:  len(products),
+		Result: products,
+	}))
+	r.At = time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	return r
+}
+
+// writeFiles creates files under a temporary directory and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestFindFiles(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"go.mod":                  "",
+		"tools/go.mod":            "",
+		"tools/main.go":           "",
+		"vendor/x/go.mod":         "",
+		"web/node_modules/go.mod": "",
+		".git/go.mod":             "",
+	})
+
+	paths, err := findFiles(root, func(name string) bool { return name == "go.mod" })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{filepath.Join(root, "go.mod"), filepath.Join(root, "tools", "go.mod")}
+	if !slices.Equal(paths, expected) {
+		t.Errorf("expected %v, got %v", expected, paths)
+	}
+
+	file := filepath.Join(root, "tools", "main.go")
+	paths, err = findFiles(file, func(name string) bool { return name == "go.mod" })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(paths, []string{file}) {
+		t.Errorf("expected the file itself, got %v", paths)
+	}
+
+	if _, err := findFiles(filepath.Join(root, "missing"), func(string) bool { return true }); err == nil {
+		t.Error("expected error for missing root")
+	}
+}
+
+func TestErrorFindings(t *testing.T) {
+	invalid := errors.New("invalid range")
+	err := errors.Join(
+		&ParseError{Component: Component{Product: "python", Version: ">=three", Field: "requires-python", Path: "pyproject.toml", Line: 3}, Err: invalid},
+		errors.New("unexpected EOF"),
+	)
+	findings := errorFindings("pyproject.toml", err)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", findings)
+	}
+	if f := findings[0]; f.Err != invalid || f.Field != "requires-python" || f.Line != 3 {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if f := findings[1]; f.Err == nil || f.Path != "pyproject.toml" || f.Product != "" {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+
+	pe := &ParseError{Component: Component{Field: "engines.node", Path: "package.json", Line: 2}, Err: invalid}
+	if pe.Error() != "package.json:2: engines.node: invalid range" || !errors.Is(pe, invalid) {
+		t.Errorf("unexpected error: %v", pe)
+	}
+}
@@ -0,0 +1,292 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// spdxAnnotator is the annotator of the annotations added to SPDX packages.
+const spdxAnnotator = "Tool: endoflife-go"
+
+// SPDX is an SPDX 2.x document in the JSON or the tag-value format. JSON
+// fields are kept as decoded, and tag-value lines as read, so that the
+// document is written back with the fields this package does not know of.
+type SPDX struct {
+	doc   map[string]any // JSON document, or nil for tag-value
+	lines []string       // tag-value document
+}
+
+// spdxPackage is a package of an SPDX document and its identifiers.
+type spdxPackage struct {
+	id, name, version string
+	refs              []identifierRef
+}
+
+// ReadSPDX reads an SPDX JSON or tag-value document, detecting its format.
+func ReadSPDX(r io.Reader) (*SPDX, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var doc map[string]any
+		if err := dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode SPDX document: %w", err)
+		}
+		if version, _ := doc["spdxVersion"].(string); !strings.HasPrefix(version, "SPDX-") {
+			return nil, errors.New("not an SPDX document: missing spdxVersion")
+		}
+		return &SPDX{doc: doc}, nil
+	}
+
+	var lines []string
+	version := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if v, ok := strings.CutPrefix(line, "SPDXVersion:"); ok && version == "" {
+			version = strings.TrimSpace(v)
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(version, "SPDX-") {
+		return nil, errors.New("not an SPDX document: missing SPDXVersion")
+	}
+	return &SPDX{lines: lines}, nil
+}
+
+// Write writes the document in the format it was read in: JSON documents as
+// indented JSON.
+func (d *SPDX) Write(w io.Writer) error {
+	if d.doc != nil {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d.doc)
+	}
+	bw := bufio.NewWriter(w)
+	for _, line := range d.lines {
+		bw.WriteString(line)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// Annotate matches the purl, then the CPE, external references of each
+// package of the document with the identifiers of endoflife.date products,
+// and resolves the version of matched packages. Each matched package is
+// given annotations of type OTHER, one per property named with
+// PropertyPrefix, with a comment of the form name=value. Annotations of
+// earlier runs are replaced. Annotations are dated with the time of the
+// resolver.
+//
+// The findings of matched packages are returned in document order. They
+// are named after the package and fielded after the identifier type that
+// matched; Path is left unset.
+func (d *SPDX) Annotate(ctx context.Context, r *Resolver) ([]Finding, error) {
+	if d.doc != nil {
+		return d.annotateJSON(ctx, r)
+	}
+	return d.annotateTagValue(ctx, r)
+}
+
+// annotateJSON annotates the packages of a JSON document in their
+// annotations field.
+func (d *SPDX) annotateJSON(ctx context.Context, r *Resolver) ([]Finding, error) {
+	date := r.now().UTC().Format(time.RFC3339)
+	packages, _ := d.doc["packages"].([]any)
+
+	var findings []Finding
+	for _, item := range packages {
+		p, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		pkg := spdxPackage{}
+		pkg.name, _ = p["name"].(string)
+		pkg.version, _ = p["versionInfo"].(string)
+		refs, _ := p["externalRefs"].([]any)
+		for _, ref := range refs {
+			if m, ok := ref.(map[string]any); ok {
+				refType, _ := m["referenceType"].(string)
+				locator, _ := m["referenceLocator"].(string)
+				pkg.addRef(refType, locator)
+			}
+		}
+
+		f, ok, err := pkg.resolve(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		annotations := removeAnnotations(p["annotations"])
+		if ok {
+			findings = append(findings, f)
+			for _, prop := range findingProperties(f) {
+				annotations = append(annotations, map[string]any{
+					"annotator":      spdxAnnotator,
+					"annotationDate": date,
+					"annotationType": "OTHER",
+					"comment":        prop.name + "=" + prop.value,
+				})
+			}
+		}
+		if len(annotations) > 0 {
+			p["annotations"] = annotations
+		} else {
+			delete(p, "annotations")
+		}
+	}
+	return findings, nil
+}
+
+// annotateTagValue annotates the packages of a tag-value document with
+// annotations appended to the document, referring to the packages by
+// SPDXREF.
+func (d *SPDX) annotateTagValue(ctx context.Context, r *Resolver) ([]Finding, error) {
+	d.lines = removeTagValueAnnotations(d.lines)
+
+	var packages []*spdxPackage
+	var current *spdxPackage
+	for i := 0; i < len(d.lines); i++ {
+		tag, value, ok := strings.Cut(d.lines[i], ":")
+		if !ok || strings.HasPrefix(strings.TrimSpace(tag), "#") {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		// Multi-line values are enclosed in <text> tags.
+		if strings.HasPrefix(value, "<text>") {
+			for !strings.Contains(d.lines[i], "</text>") && i+1 < len(d.lines) {
+				i++
+			}
+			continue
+		}
+
+		switch strings.TrimSpace(tag) {
+		case "PackageName":
+			current = &spdxPackage{name: value}
+			packages = append(packages, current)
+		case "FileName", "SnippetSPDXID", "LicenseID":
+			current = nil
+		case "SPDXID":
+			if current != nil && current.id == "" {
+				current.id = value
+			}
+		case "PackageVersion":
+			if current != nil {
+				current.version = value
+			}
+		case "ExternalRef":
+			// ExternalRef: <category> <type> <locator>
+			if fields := strings.Fields(value); current != nil && len(fields) == 3 {
+				current.addRef(fields[1], fields[2])
+			}
+		}
+	}
+
+	date := r.now().UTC().Format(time.RFC3339)
+	var findings []Finding
+	for _, pkg := range packages {
+		f, ok, err := pkg.resolve(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		findings = append(findings, f)
+		if pkg.id == "" {
+			continue
+		}
+		for _, prop := range findingProperties(f) {
+			d.lines = append(d.lines,
+				"",
+				"Annotator: "+spdxAnnotator,
+				"AnnotationDate: "+date,
+				"AnnotationType: OTHER",
+				"SPDXREF: "+pkg.id,
+				"AnnotationComment: <text>"+prop.name+"="+prop.value+"</text>",
+			)
+		}
+	}
+	return findings, nil
+}
+
+// addRef adds an external reference to the identifiers of the package if it
+// is a package URL or a CPE name.
+func (p *spdxPackage) addRef(refType, locator string) {
+	switch refType {
+	case "purl":
+		p.refs = append(p.refs, identifierRef{field: "purl", id: locator})
+	case "cpe23Type", "cpe22Type":
+		p.refs = append(p.refs, identifierRef{field: "cpe", id: locator})
+	}
+}
+
+// resolve matches the package with a product, preferring package URLs to
+// CPE names, and resolves its version.
+func (p *spdxPackage) resolve(ctx context.Context, r *Resolver) (Finding, bool, error) {
+	refs := slices.Clone(p.refs)
+	slices.SortStableFunc(refs, func(a, b identifierRef) int {
+		return strings.Compare(b.field, a.field) // purl before cpe
+	})
+	return resolveIdentifiers(ctx, r, p.name, p.version, refs)
+}
+
+// removeAnnotations returns the annotations of a JSON package, without those
+// whose comment is a property named with PropertyPrefix.
+func removeAnnotations(v any) []any {
+	annotations, _ := v.([]any)
+	kept := make([]any, 0, len(annotations))
+	for _, a := range annotations {
+		if m, ok := a.(map[string]any); ok {
+			if comment, _ := m["comment"].(string); strings.HasPrefix(comment, PropertyPrefix) {
+				continue
+			}
+		}
+		kept = append(kept, a)
+	}
+	return kept
+}
+
+// removeTagValueAnnotations returns the lines of a tag-value document
+// without the annotations whose comment is a property named with
+// PropertyPrefix, and the blank line preceding each.
+func removeTagValueAnnotations(lines []string) []string {
+	var kept []string
+	for i := 0; i < len(lines); i++ {
+		if !strings.HasPrefix(lines[i], "Annotator:") {
+			kept = append(kept, lines[i])
+			continue
+		}
+		// An annotation ends with its comment.
+		end := i
+		for end < len(lines) && !strings.HasPrefix(lines[end], "AnnotationComment:") {
+			end++
+		}
+		if end < len(lines) {
+			comment := strings.TrimSpace(strings.TrimPrefix(lines[end], "AnnotationComment:"))
+			if strings.HasPrefix(strings.TrimPrefix(comment, "<text>"), PropertyPrefix) {
+				if n := len(kept); n > 0 && strings.TrimSpace(kept[n-1]) == "" {
+					kept = kept[:n-1]
+				}
+				i = end
+				continue
+			}
+		}
+		kept = append(kept, lines[i])
+	}
+	return kept
+}
@@ -0,0 +1,224 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const testSPDXJSON = `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "app",
+  "documentNamespace": "https://example.com/app",
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-Package-python",
+      "name": "python",
+      "versionInfo": "3.8.18",
+      "externalRefs": [
+        {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:unknown:unknown:1.0:*:*:*:*:*:*:*"},
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:docker/python@3.8.18"}
+      ],
+      "annotations": [
+        {"annotator": "Tool: endoflife-go", "annotationDate": "2024-01-01T00:00:00Z", "annotationType": "OTHER", "comment": "endoflife:phase=active"},
+        {"annotator": "Person: Jane", "annotationDate": "2024-01-01T00:00:00Z", "annotationType": "REVIEW", "comment": "Reviewed."}
+      ]
+    },
+    {
+      "SPDXID": "SPDXRef-Package-ubuntu",
+      "name": "ubuntu",
+      "externalRefs": [
+        {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:o:canonical:ubuntu_linux:22.04:*:*:*:*:*:*:*"}
+      ]
+    },
+    {
+      "SPDXID": "SPDXRef-Package-left-pad",
+      "name": "left-pad",
+      "versionInfo": "1.3.0",
+      "externalRefs": [
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/left-pad@1.3.0"}
+      ]
+    }
+  ]
+}`
+
+const testSPDXTagValue = `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: app
+
+PackageName: Django
+SPDXID: SPDXRef-Package-django
+PackageVersion: 4.2.7
+PackageComment: <text>Web framework.
+ExternalRef: PACKAGE-MANAGER purl pkg:npm/not-a-ref@1.0
+</text>
+ExternalRef: PACKAGE-MANAGER purl pkg:pypi/django@4.2.7
+
+FileName: ./manage.py
+SPDXID: SPDXRef-File-manage
+ExternalRef: PACKAGE-MANAGER purl pkg:pypi/django@1.0
+
+PackageName: left-pad
+SPDXID: SPDXRef-Package-left-pad
+PackageVersion: 1.3.0
+ExternalRef: PACKAGE-MANAGER purl pkg:npm/left-pad@1.3.0
+
+Annotator: Tool: endoflife-go
+AnnotationDate: 2024-01-01T00:00:00Z
+AnnotationType: OTHER
+SPDXREF: SPDXRef-Package-django
+AnnotationComment: <text>endoflife:phase=active</text>
+
+Annotator: Person: Jane
+AnnotationDate: 2024-01-01T00:00:00Z
+AnnotationType: REVIEW
+SPDXREF: SPDXRef-Package-django
+AnnotationComment: <text>Reviewed.</text>
+`
+
+// spdxComments returns the comments of the annotations of a decoded SPDX
+// JSON package.
+func spdxComments(p map[string]any) []string {
+	var comments []string
+	list, _ := p["annotations"].([]any)
+	for _, a := range list {
+		comments = append(comments, a.(map[string]any)["comment"].(string))
+	}
+	return comments
+}
+
+func TestSPDX_AnnotateJSON(t *testing.T) {
+	doc, err := ReadSPDX(strings.NewReader(testSPDXJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	findings, err := doc.Annotate(context.Background(), testResolver())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d", len(findings))
+	}
+	if f := findings[0]; f.Err != nil || f.Name != "python" || f.Field != "purl" || f.Release.Name != "3.8" {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if f := findings[1]; f.Err != nil || f.Name != "ubuntu" || f.Field != "cpe" || f.Release.Name != "22.04" {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to decode output: %v", err)
+	}
+	if out["documentNamespace"] != "https://example.com/app" {
+		t.Errorf("expected unknown fields to be preserved, got %v", out["documentNamespace"])
+	}
+
+	packages := out["packages"].([]any)
+	python := packages[0].(map[string]any)
+	expected := []string{
+		"Reviewed.",
+		"endoflife:product=python",
+		"endoflife:release=3.8",
+		"endoflife:phase=eol",
+		"endoflife:eol=2024-10-07",
+		"endoflife:latest=3.8.20",
+	}
+	if comments := spdxComments(python); strings.Join(comments, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected annotations %q, got %q", expected, comments)
+	}
+	annotation := python["annotations"].([]any)[1].(map[string]any)
+	if annotation["annotator"] != "Tool: endoflife-go" || annotation["annotationType"] != "OTHER" || annotation["annotationDate"] != "2025-01-15T00:00:00Z" {
+		t.Errorf("unexpected annotation: %v", annotation)
+	}
+	if _, ok := packages[2].(map[string]any)["annotations"]; ok {
+		t.Errorf("expected unmatched package to be left alone")
+	}
+}
+
+func TestSPDX_AnnotateTagValue(t *testing.T) {
+	doc, err := ReadSPDX(strings.NewReader(testSPDXTagValue))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	findings, err := doc.Annotate(context.Background(), testResolver())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	if f := findings[0]; f.Err != nil || f.Name != "Django" || f.Product != "django" || f.Version != "4.2.7" || f.Release.Name != "4.2" {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+
+	// Annotating twice replaces the annotations of the first run.
+	if _, err := doc.Annotate(context.Background(), testResolver()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "endoflife:phase=active") || strings.Count(out, "endoflife:phase=security") != 1 {
+		t.Errorf("expected stale annotations to be replaced, got:\n%s", out)
+	}
+	for _, want := range []string{
+		"AnnotationComment: <text>Reviewed.</text>",
+		"SPDXREF: SPDXRef-Package-django\nAnnotationComment: <text>endoflife:eol=2026-04-30</text>",
+		"AnnotationDate: 2025-01-15T00:00:00Z",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if !strings.HasPrefix(out, testSPDXTagValue[:strings.Index(testSPDXTagValue, "Annotator:")]) {
+		t.Errorf("expected document to be preserved, got:\n%s", out)
+	}
+}
+
+func TestReadSPDX_Invalid(t *testing.T) {
+	for _, doc := range []string{`{`, `{"bomFormat": "CycloneDX"}`, "PackageName: foo\n"} {
+		if _, err := ReadSPDX(strings.NewReader(doc)); err == nil {
+			t.Errorf("expected error for %s", doc)
+		}
+	}
+}
+
+func TestReadSBOM(t *testing.T) {
+	tests := []struct {
+		doc      string
+		expected string
+	}{
+		{doc: testCycloneDX, expected: "*scan.CycloneDX"},
+		{doc: testSPDXJSON, expected: "*scan.SPDX"},
+		{doc: testSPDXTagValue, expected: "*scan.SPDX"},
+	}
+	for _, tt := range tests {
+		doc, err := ReadSBOM(strings.NewReader(tt.doc))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if got := fmt.Sprintf("%T", doc); got != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, got)
+		}
+	}
+
+	if _, err := ReadSBOM(strings.NewReader(`{"name": "app"}`)); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
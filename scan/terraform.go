@@ -0,0 +1,401 @@
+package scan
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// terraformResource describes the attribute of a resource type pinning the
+// version of a managed service.
+type terraformResource struct {
+	// attribute is the name of the attribute holding the version.
+	attribute string
+
+	// components maps the version and the constant attributes of a
+	// resource to the components it pins, or to none for untracked
+	// services.
+	components func(version string, attrs map[string]string) []Component
+}
+
+// terraformResources are the resource types pinning the versions of
+// managed services.
+var terraformResources = map[string]terraformResource{
+	"aws_db_instance":                      {attribute: "engine_version", components: rdsEngine},
+	"aws_rds_cluster":                      {attribute: "engine_version", components: rdsEngine},
+	"aws_elasticache_cluster":              {attribute: "engine_version", components: elastiCacheEngine},
+	"aws_elasticache_replication_group":    {attribute: "engine_version", components: elastiCacheEngine},
+	"aws_lambda_function":                  {attribute: "runtime", components: lambdaRuntime},
+	"aws_eks_cluster":                      {attribute: "version", components: managedCluster("amazon-eks")},
+	"aws_eks_node_group":                   {attribute: "version", components: managedCluster("amazon-eks")},
+	"azurerm_kubernetes_cluster":           {attribute: "kubernetes_version", components: managedCluster("azure-kubernetes-service")},
+	"azurerm_kubernetes_cluster_node_pool": {attribute: "orchestrator_version", components: managedCluster("azure-kubernetes-service")},
+	"google_container_cluster":             {attribute: "min_master_version", components: managedCluster("google-kubernetes-engine")},
+	"google_container_node_pool":           {attribute: "version", components: managedCluster("google-kubernetes-engine")},
+}
+
+// rdsEngines maps the engines of RDS instances and clusters to products.
+var rdsEngines = map[string]string{
+	"aurora":            "amazon-aurora-mysql",
+	"aurora-mysql":      "amazon-aurora-mysql",
+	"aurora-postgresql": "amazon-aurora-postgresql",
+	"mariadb":           "amazon-rds-mariadb",
+	"mysql":             "amazon-rds-mysql",
+	"postgres":          "amazon-rds-postgresql",
+}
+
+// auroraMySQLVersions maps the MySQL versions that Aurora MySQL is
+// compatible with to its major versions.
+var auroraMySQLVersions = map[string]string{
+	"5.6": "1",
+	"5.7": "2",
+	"8.0": "3",
+}
+
+// rdsEngine maps the engine version of an RDS instance or cluster to the
+// product of its engine.
+func rdsEngine(version string, attrs map[string]string) []Component {
+	product, ok := rdsEngines[attrs["engine"]]
+	if !ok {
+		return nil
+	}
+	if product == "amazon-aurora-mysql" {
+		// Aurora MySQL versions, as in 8.0.mysql_aurora.3.05.2, start with
+		// the MySQL version they are compatible with.
+		if _, v, ok := strings.Cut(version, ".mysql_aurora."); ok {
+			version = v
+		} else if v, ok := auroraMySQLVersions[version]; ok {
+			version = v
+		}
+	}
+	return []Component{{Product: product, Version: version}}
+}
+
+// elastiCacheEngine maps the engine version of an ElastiCache cluster to
+// redis or valkey, whose releases ElastiCache versions follow. Versions
+// such as 6.x stand for the latest version of a release.
+func elastiCacheEngine(version string, attrs map[string]string) []Component {
+	engine := cmp.Or(attrs["engine"], "redis")
+	if engine != "redis" && engine != "valkey" {
+		return nil
+	}
+	return []Component{{Product: engine, Version: strings.TrimSuffix(version, ".x")}}
+}
+
+// lambdaRuntime maps the runtime of a Lambda function as described for
+// ParseLambdaRuntime.
+func lambdaRuntime(runtime string, _ map[string]string) []Component {
+	return ParseLambdaRuntime(runtime)
+}
+
+// managedCluster returns a function mapping the Kubernetes version of a
+// cluster or node pool to the minor version of a managed service.
+func managedCluster(product string) func(string, map[string]string) []Component {
+	return func(version string, _ map[string]string) []Component {
+		cs := kubernetesVersion(version, product)
+		if len(cs) == 0 {
+			return nil
+		}
+		return []Component{{Product: product, Version: kubernetesMinor(cs[0].Version)}}
+	}
+}
+
+// ParseTerraform returns the versions pinned by a Terraform configuration
+// file:
+//
+//   - terraform of the required_version constraint of the terraform block;
+//   - the version constraints of required_providers, for the providers
+//     that providers maps to products by source address, such as
+//     hashicorp/aws. Providers have no lifecycle on endoflife.date of their
+//     own, so no provider is mapped by default;
+//   - the versions of managed services pinned by well-known resource
+//     attributes: engine_version of RDS and ElastiCache, runtime of Lambda
+//     functions, as described for ParseLambdaRuntime, and the Kubernetes
+//     version of EKS, AKS and GKE clusters and node pools.
+//
+// Attributes referring to variables or other objects are skipped.
+// Components of resources carry the resource as type.name, and are named
+// after the version as written.
+//
+// Errors are *ParseErrors, joined if there are several. Invalid version
+// constraints do not stop parsing: the other components are returned along
+// with the error.
+func ParseTerraform(path string, data []byte, providers map[string]string) ([]Component, error) {
+	body, err := parseHCL(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var components []Component
+	var errs []error
+	for _, block := range body.Blocks {
+		switch {
+		case block.Type == "terraform":
+			c, err := parseTerraformSettings(path, block.Body, providers)
+			components = append(components, c...)
+			errs = append(errs, err...)
+		case block.Type == "resource" && len(block.Labels) == 2:
+			components = append(components, parseTerraformResource(path, block)...)
+		}
+	}
+	return components, errors.Join(errs...)
+}
+
+// parseTerraformSettings returns the versions pinned by a terraform block,
+// and the errors of invalid version constraints.
+func parseTerraformSettings(path string, body *hclsyntax.Body, providers map[string]string) ([]Component, []error) {
+	var components []Component
+	var errs []error
+	add := func(c Component) {
+		r, err := ParseTerraformRange(c.Version)
+		if err != nil {
+			errs = append(errs, &ParseError{Component: c, Err: err})
+			return
+		}
+		c.Range = r
+		components = append(components, c)
+	}
+
+	if attr, ok := body.Attributes["required_version"]; ok {
+		if constraint, ok := hclString(attr.Expr); ok {
+			add(Component{
+				Product: "terraform",
+				Version: constraint,
+				Field:   "required_version",
+				Path:    path,
+				Line:    attr.SrcRange.Start.Line,
+			})
+		}
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "required_providers" {
+			continue
+		}
+		for _, attr := range sortedAttributes(block.Body) {
+			// Providers are given a source and a version, or only a
+			// version, before Terraform 0.13, implying a hashicorp
+			// provider.
+			source, constraint := "hashicorp/"+attr.Name, ""
+			if obj, ok := attr.Expr.(*hclsyntax.ObjectConsExpr); ok {
+				for _, item := range obj.Items {
+					key, _ := hclString(item.KeyExpr)
+					value, _ := hclString(item.ValueExpr)
+					switch key {
+					case "source":
+						source = value
+					case "version":
+						constraint = value
+					}
+				}
+			} else {
+				constraint, _ = hclString(attr.Expr)
+			}
+
+			product, ok := providerProduct(providers, source)
+			if !ok || constraint == "" {
+				continue
+			}
+			add(Component{
+				Product: product,
+				Version: constraint,
+				Name:    providerSource(source),
+				Field:   "required_providers",
+				Path:    path,
+				Line:    attr.SrcRange.Start.Line,
+			})
+		}
+	}
+	return components, errs
+}
+
+// parseTerraformResource returns the versions of a managed service pinned
+// by a resource block, if any.
+func parseTerraformResource(path string, block *hclsyntax.Block) []Component {
+	res, ok := terraformResources[block.Labels[0]]
+	if !ok {
+		return nil
+	}
+	attr, ok := block.Body.Attributes[res.attribute]
+	if !ok {
+		return nil
+	}
+	version, ok := hclString(attr.Expr)
+	if !ok {
+		return nil
+	}
+	attrs := make(map[string]string)
+	for name, a := range block.Body.Attributes {
+		if s, ok := hclString(a.Expr); ok {
+			attrs[name] = s
+		}
+	}
+	components := res.components(version, attrs)
+	for i := range components {
+		components[i].Name = version
+		components[i].Field = res.attribute
+		components[i].Resource = block.Labels[0] + "." + block.Labels[1]
+		components[i].Path = path
+		components[i].Line = attr.SrcRange.Start.Line
+	}
+	return components
+}
+
+// ParseTerraformLock returns the versions of the providers selected by a
+// .terraform.lock.hcl file, for the providers that providers maps to
+// products, as described for ParseTerraform. Other providers are skipped,
+// so a lock file yields no components if providers is nil.
+func ParseTerraformLock(path string, data []byte, providers map[string]string) ([]Component, error) {
+	body, err := parseHCL(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var components []Component
+	for _, block := range body.Blocks {
+		if block.Type != "provider" || len(block.Labels) != 1 {
+			continue
+		}
+		product, ok := providerProduct(providers, block.Labels[0])
+		if !ok {
+			continue
+		}
+		attr, ok := block.Body.Attributes["version"]
+		if !ok {
+			continue
+		}
+		if version, ok := hclString(attr.Expr); ok {
+			components = append(components, Component{
+				Product: product,
+				Version: version,
+				Name:    providerSource(block.Labels[0]),
+				Field:   "version",
+				Path:    path,
+				Line:    attr.SrcRange.Start.Line,
+			})
+		}
+	}
+	return components, nil
+}
+
+// parseHCL parses the body of an HCL file in native syntax. Syntax errors
+// are returned as *ParseErrors, joined if there are several.
+func parseHCL(path string, data []byte) (*hclsyntax.Body, error) {
+	file, diags := hclsyntax.ParseConfig(data, path, hcl.InitialPos)
+	if !diags.HasErrors() {
+		return file.Body.(*hclsyntax.Body), nil
+	}
+	var errs []error
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+		c := Component{Product: "terraform", Path: path}
+		if diag.Subject != nil {
+			c.Line = diag.Subject.Start.Line
+		}
+		msg := diag.Summary
+		if diag.Detail != "" {
+			msg += "; " + diag.Detail
+		}
+		errs = append(errs, &ParseError{Component: c, Err: errors.New(msg)})
+	}
+	return nil, errors.Join(errs...)
+}
+
+// sortedAttributes returns the attributes of a body in source order.
+func sortedAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attrs := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attr := range body.Attributes {
+		attrs = append(attrs, attr)
+	}
+	slices.SortFunc(attrs, func(a, b *hclsyntax.Attribute) int {
+		return a.SrcRange.Start.Byte - b.SrcRange.Start.Byte
+	})
+	return attrs
+}
+
+// hclString returns the value of a constant expression as a string. It
+// returns false for expressions referring to variables or other objects,
+// and for values that are neither strings nor numbers.
+func hclString(expr hclsyntax.Expression) (string, bool) {
+	v, diags := expr.Value(nil)
+	if diags.HasErrors() || v.IsNull() || !v.IsWhollyKnown() || !v.Type().IsPrimitiveType() || v.Type() == cty.Bool {
+		return "", false
+	}
+	v, err := convert.Convert(v, cty.String)
+	if err != nil {
+		return "", false
+	}
+	return v.AsString(), true
+}
+
+// providerSource returns the short, lower-case form of a provider source
+// address, such as hashicorp/aws for registry.terraform.io/hashicorp/aws.
+func providerSource(source string) string {
+	source = strings.ToLower(source)
+	for _, registry := range []string{"registry.terraform.io/", "registry.opentofu.org/"} {
+		source = strings.TrimPrefix(source, registry)
+	}
+	return source
+}
+
+// providerProduct returns the product that providers maps a provider
+// source address to, in any form.
+func providerProduct(providers map[string]string, source string) (string, bool) {
+	source = providerSource(source)
+	for s, product := range providers {
+		if providerSource(s) == source {
+			return product, true
+		}
+	}
+	return "", false
+}
+
+// isTerraform reports whether name is the name of a Terraform
+// configuration file or dependency lock file.
+func isTerraform(name string) bool {
+	return filepath.Ext(name) == ".tf" || name == ".terraform.lock.hcl"
+}
+
+// ScanTerraform finds every Terraform configuration file and dependency
+// lock file under root and resolves the versions they pin, as described
+// for ParseTerraform. Provider versions are resolved for the providers that
+// providers maps to products by source address, and ignored for the others;
+// providers may be nil. The
+// .terraform directories of downloaded modules are skipped. Files that
+// cannot be parsed and invalid version constraints are reported as findings
+// with Err set.
+func ScanTerraform(ctx context.Context, r *Resolver, root string, providers map[string]string) ([]Finding, error) {
+	paths, err := findFiles(root, isTerraform)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		parse := ParseTerraform
+		if filepath.Base(path) == ".terraform.lock.hcl" {
+			parse = ParseTerraformLock
+		}
+		c, err := parse(path, data, providers)
+		findings = append(findings, r.ResolveAll(ctx, c)...)
+		if err != nil {
+			findings = append(findings, errorFindings(path, err)...)
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,180 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+const testTerraform = `terraform {
+  required_version = ">= 1.5.0, < 2.0.0"
+
+  required_providers {
+    aws = {
+      source                = "hashicorp/aws"
+      version               = "~> 5.31"
+      configuration_aliases = [aws.west]
+    }
+    random = "~> 3.6"
+  }
+}
+
+resource "aws_db_instance" "main" {
+  engine         = "postgres"
+  engine_version = "15.4"
+  instance_class = var.instance_class
+}
+
+resource "aws_rds_cluster" "reporting" {
+  engine         = "aurora-mysql"
+  engine_version = "8.0.mysql_aurora.3.05.2"
+}
+
+resource "aws_db_instance" "legacy" {
+  engine         = "sqlserver-ex"
+  engine_version = "15.00"
+}
+
+resource "aws_elasticache_replication_group" "cache" {
+  engine_version = "6.x"
+}
+
+resource "aws_lambda_function" "api" {
+  function_name = "api"
+  runtime       = "python3.8"
+}
+
+resource "aws_eks_cluster" "main" {
+  name    = "main"
+  version = 1.29
+}
+
+resource "google_container_cluster" "main" {
+  min_master_version = "1.30.5-gke.1014001"
+}
+
+resource "azurerm_kubernetes_cluster" "main" {
+  kubernetes_version = var.kubernetes_version
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.5.1"
+}
+`
+
+func TestParseTerraform(t *testing.T) {
+	providers := map[string]string{"registry.terraform.io/hashicorp/aws": "terraform-provider-aws"}
+	components, err := ParseTerraform("main.tf", []byte(testTerraform), providers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Component{
+		{Product: "terraform", Version: ">= 1.5.0, < 2.0.0", Field: "required_version", Path: "main.tf", Line: 2},
+		{Product: "terraform-provider-aws", Version: "~> 5.31", Name: "hashicorp/aws", Field: "required_providers", Path: "main.tf", Line: 5},
+		{Product: "amazon-rds-postgresql", Version: "15.4", Name: "15.4", Field: "engine_version", Resource: "aws_db_instance.main", Path: "main.tf", Line: 16},
+		{Product: "amazon-aurora-mysql", Version: "3.05.2", Name: "8.0.mysql_aurora.3.05.2", Field: "engine_version", Resource: "aws_rds_cluster.reporting", Path: "main.tf", Line: 22},
+		{Product: "redis", Version: "6", Name: "6.x", Field: "engine_version", Resource: "aws_elasticache_replication_group.cache", Path: "main.tf", Line: 31},
+		{Product: "aws-lambda", Version: "python3.8", Name: "python3.8", Field: "runtime", Resource: "aws_lambda_function.api", Path: "main.tf", Line: 36},
+		{Product: "python", Version: "3.8", Name: "python3.8", Field: "runtime", Resource: "aws_lambda_function.api", Path: "main.tf", Line: 36},
+		{Product: "amazon-eks", Version: "1.29", Name: "1.29", Field: "version", Resource: "aws_eks_cluster.main", Path: "main.tf", Line: 41},
+		{Product: "google-kubernetes-engine", Version: "1.30", Name: "1.30.5-gke.1014001", Field: "min_master_version", Resource: "google_container_cluster.main", Path: "main.tf", Line: 45},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), components)
+	}
+	for i, c := range components {
+		c.Range = nil
+		if c != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], c)
+		}
+	}
+	if components[0].Range == nil || !components[0].Range.AllowsRelease("1.9") {
+		t.Errorf("expected required_version to allow 1.9, got %v", components[0].Range)
+	}
+
+	var pe *ParseError
+	if _, err := ParseTerraform("broken.tf", []byte("terraform {\n"), nil); !errors.As(err, &pe) || pe.Path != "broken.tf" || pe.Line == 0 {
+		t.Errorf("expected located error for invalid HCL, got %v", err)
+	}
+
+	data := []byte("terraform {\n  required_version = \">= one\"\n}\n\nresource \"aws_eks_cluster\" \"main\" {\n  version = \"1.29\"\n}\n")
+	components, err = ParseTerraform("bad.tf", data, nil)
+	if !errors.As(err, &pe) || pe.Field != "required_version" || pe.Version != ">= one" || pe.Line != 2 {
+		t.Errorf("expected parse error for required_version on line 2, got %v", err)
+	}
+	if len(components) != 1 || components[0].Product != "amazon-eks" {
+		t.Errorf("expected the other components to be returned along with the error, got %+v", components)
+	}
+}
+
+func TestParseTerraformLock(t *testing.T) {
+	data := []byte(`# This file is maintained automatically by "terraform init".
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.31.0"
+  constraints = "~> 5.31"
+  hashes = [
+    "h1:ltxyuBWIy9cq0kIKDJH1jeWJy/y7XJLjS4QrsQK4plA=",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.6.0"
+}
+`)
+	components, err := ParseTerraformLock(".terraform.lock.hcl", data, map[string]string{"hashicorp/aws": "terraform-provider-aws"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := Component{Product: "terraform-provider-aws", Version: "5.31.0", Name: "hashicorp/aws", Field: "version", Path: ".terraform.lock.hcl", Line: 4}
+	if len(components) != 1 || components[0] != expected {
+		t.Errorf("expected %+v, got %+v", expected, components)
+	}
+
+	components, err = ParseTerraformLock(".terraform.lock.hcl", data, nil)
+	if err != nil || len(components) != 0 {
+		t.Errorf("expected no components without provider mappings, got %+v (%v)", components, err)
+	}
+}
+
+func TestScanTerraform(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"infra/versions.tf":             "terraform {\n  required_version = \"~> 1.5.0\"\n}\n",
+		"infra/eks.tf":                  "resource \"aws_eks_cluster\" \"main\" {\n  version = \"1.30\"\n}\n",
+		"infra/.terraform.lock.hcl":     "provider \"registry.terraform.io/hashicorp/aws\" {\n  version = \"5.31.0\"\n}\n",
+		"infra/.terraform/modules/x.tf": "terraform {\n  required_version = \">= 0.12\"\n}\n",
+		"infra/terraform.tfvars":        "region = \"us-east-1\"\n",
+		"infra/broken.tf":               "resource \"aws_eks_cluster\" {\n",
+		"legacy/versions.tf":            "terraform {\n  required_version = \">= latest\"\n}\n",
+	})
+
+	findings, err := ScanTerraform(context.Background(), testResolver(), root, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 4 {
+		t.Fatalf("expected 4 findings, got %+v", findings)
+	}
+
+	// Findings are in path order: broken.tf, eks.tf and versions.tf of
+	// infra, then legacy.
+	if broken := findings[0]; broken.Err == nil || broken.Path != filepath.Join(root, "infra", "broken.tf") || broken.Line == 0 {
+		t.Errorf("expected located error for invalid HCL, got %+v", broken)
+	}
+	eks := findings[1]
+	if eks.Product != "amazon-eks" || eks.Resource != "aws_eks_cluster.main" || eks.Err == nil {
+		t.Errorf("expected error for product missing from fixtures, got %+v", eks)
+	}
+	if legacy := findings[3]; legacy.Err == nil || legacy.Field != "required_version" || legacy.Range != nil || legacy.Line != 2 {
+		t.Errorf("expected error for invalid required_version, got %+v", legacy)
+	}
+	f := findings[2]
+	if f.Err != nil || f.Release.Name != "1.5" || !f.IsEOL() {
+		t.Errorf("expected end-of-life release 1.5, got %+v", f)
+	}
+	if f.Path != filepath.Join(root, "infra", "versions.tf") || f.Line != 2 {
+		t.Errorf("unexpected source: %s:%d", f.Path, f.Line)
+	}
+}
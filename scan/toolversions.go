@@ -0,0 +1,245 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/shmokmt/endoflife-go"
+	"github.com/shmokmt/endoflife-go/internal/numver"
+)
+
+// toolProducts maps asdf plugin and mise tool names to endoflife.date
+// products. Tools that are not listed are looked up by product name and
+// alias with Resolver.ProductName.
+var toolProducts = map[string]string{
+	"bun":         "bun",
+	"deno":        "deno",
+	"dotnet":      "dotnet",
+	"dotnet-core": "dotnet",
+	"elixir":      "elixir",
+	"erlang":      "erlang",
+	"go":          "go",
+	"golang":      "go",
+	"gradle":      "gradle",
+	"helm":        "helm",
+	"kotlin":      "kotlin",
+	"kubectl":     "kubernetes",
+	"maven":       "maven",
+	"node":        "nodejs",
+	"nodejs":      "nodejs",
+	"perl":        "perl",
+	"php":         "php",
+	"postgres":    "postgresql",
+	"postgresql":  "postgresql",
+	"python":      "python",
+	"redis":       "redis",
+	"ruby":        "ruby",
+	"rust":        "rust",
+	"scala":       "scala",
+	"terraform":   "terraform",
+}
+
+// javaVendors maps the distribution prefixes of Java versions, as in
+// temurin-17.0.9+9, to endoflife.date products.
+var javaVendors = map[string]string{
+	"adoptopenjdk": "eclipse-temurin",
+	"corretto":     "amazon-corretto",
+	"liberica":     "bellsoft-liberica",
+	"microsoft":    "microsoft-build-of-openjdk",
+	"openjdk":      "openjdk-builds-from-oracle",
+	"oracle":       "oracle-jdk",
+	"redhat":       "redhat-build-of-openjdk",
+	"sapmachine":   "sapmachine",
+	"semeru":       "ibm-semeru-runtime",
+	"temurin":      "eclipse-temurin",
+	"zulu":         "azul-zulu",
+}
+
+// knownToolProducts are the products of toolProducts and javaVendors,
+// which need no lookup.
+var knownToolProducts = func() map[string]bool {
+	known := make(map[string]bool)
+	for _, m := range []map[string]string{toolProducts, javaVendors} {
+		for _, product := range m {
+			known[product] = true
+		}
+	}
+	return known
+}()
+
+// toolVersion maps a tool and one of its versions to a product and a
+// version. It returns false for versions that do not pin a release, such as
+// latest, system, ref:main or path:/opt/node.
+func toolVersion(tool, version string) (product, v string, ok bool) {
+	tool = strings.ToLower(strings.TrimPrefix(tool, "core:"))
+	version = strings.TrimPrefix(version, "prefix:")
+
+	product = toolProducts[tool]
+	if product == "" {
+		product = tool
+	}
+	if tool == "java" {
+		// Java versions are prefixed by their distribution, except for
+		// OpenJDK builds.
+		product = javaVendors["openjdk"]
+		if vendor, rest, found := strings.Cut(version, "-"); found {
+			if p, ok := javaVendors[strings.ToLower(vendor)]; ok {
+				product, version = p, rest
+			}
+		}
+	}
+
+	version = strings.TrimPrefix(version, "v")
+	if version == "" || !numver.IsDigit(version[0]) {
+		return "", "", false
+	}
+	return product, version, true
+}
+
+// ParseToolVersions returns the versions pinned by an asdf .tool-versions
+// file. Each line names a tool followed by one or more versions, which are
+// all returned. Components are fielded after the tool name as written, and
+// refer to the product of the tool in the table of known tools, or to the
+// tool name itself otherwise.
+func ParseToolVersions(path string, data []byte) ([]Component, error) {
+	var components []Component
+	for i, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, version := range fields[1:] {
+			product, v, ok := toolVersion(fields[0], version)
+			if !ok {
+				continue
+			}
+			components = append(components, Component{
+				Product: product,
+				Version: v,
+				Field:   fields[0],
+				Path:    path,
+				Line:    i + 1,
+			})
+		}
+	}
+	return components, nil
+}
+
+// ParseMiseToml returns the versions pinned in the tools table of a
+// mise.toml file, as described for ParseToolVersions. A tool may be given a
+// version, a list of versions, or a table with a version key. Tools of
+// backends other than core, such as npm:prettier, are skipped. Errors are
+// *ParseErrors.
+func ParseMiseToml(path string, data []byte) ([]Component, error) {
+	var config struct {
+		Tools map[string]any `toml:"tools"`
+	}
+	if err := toml.Unmarshal(data, &config); err != nil {
+		return nil, tomlError(path, "", data, err)
+	}
+
+	var components []Component
+	for _, tool := range slices.Sorted(maps.Keys(config.Tools)) {
+		if strings.Contains(strings.TrimPrefix(tool, "core:"), ":") {
+			continue
+		}
+		var versions []string
+		switch v := config.Tools[tool].(type) {
+		case string:
+			versions = append(versions, v)
+		case []any:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					versions = append(versions, s)
+				}
+			}
+		case map[string]any:
+			if s, ok := v["version"].(string); ok {
+				versions = append(versions, s)
+			}
+		}
+		for _, version := range versions {
+			product, v, ok := toolVersion(tool, version)
+			if !ok {
+				continue
+			}
+			components = append(components, Component{
+				Product: product,
+				Version: v,
+				Field:   tool,
+				Path:    path,
+				Line:    tomlKeyLine(data, "tools", tool),
+			})
+		}
+	}
+	// Report tools in the order of the file.
+	slices.SortStableFunc(components, func(a, b Component) int { return a.Line - b.Line })
+	return components, nil
+}
+
+// miseConfig matches the names of mise configuration files, such as
+// mise.toml, .mise.local.toml or mise.production.toml.
+var miseConfig = regexp.MustCompile(`^\.?mise(\.[\w-]+)?\.toml$`)
+
+// ScanToolVersions finds every .tool-versions and mise.toml file under root
+// and resolves the versions they pin. Tools missing from the table of known
+// tools are mapped to products by name or alias with Resolver.ProductName,
+// and skipped if no product matches. Files that cannot be parsed are
+// reported as findings with Err set.
+func ScanToolVersions(ctx context.Context, r *Resolver, root string) ([]Finding, error) {
+	paths, err := findFiles(root, func(name string) bool {
+		return name == ".tool-versions" || miseConfig.MatchString(name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		parse := ParseMiseToml
+		if filepath.Base(path) == ".tool-versions" {
+			parse = ParseToolVersions
+		}
+		c, err := parse(path, data)
+		findings = append(findings, r.ResolveAll(ctx, knownTools(ctx, r, c))...)
+		if err != nil {
+			findings = append(findings, errorFindings(path, err)...)
+		}
+	}
+	return findings, nil
+}
+
+// knownTools maps the tools of components missing from the table of known
+// tools to products with Resolver.ProductName, and drops those matching no
+// product.
+func knownTools(ctx context.Context, r *Resolver, components []Component) []Component {
+	known := components[:0]
+	for _, c := range components {
+		if !knownToolProducts[c.Product] {
+			product, err := r.ProductName(ctx, c.Product)
+			switch {
+			case errors.Is(err, endoflife.ErrNoMatchingProduct):
+				// Most tools, such as linters and CLIs, are not tracked.
+				continue
+			case err == nil:
+				c.Product = product
+			}
+			// On other errors, the product name is kept and the lookup
+			// error is reported when resolving.
+		}
+		known = append(known, c)
+	}
+	return known
+}
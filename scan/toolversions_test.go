@@ -0,0 +1,130 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseToolVersions(t *testing.T) {
+	data := []byte(`# runtimes
+nodejs 20.11.1 18.19.0
+golang 1.22.1
+java temurin-17.0.9+9
+ruby system
+terraform ref:main
+kubectl v1.29.2
+`)
+
+	components, err := ParseToolVersions(".tool-versions", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Component{
+		{Product: "nodejs", Version: "20.11.1", Field: "nodejs", Path: ".tool-versions", Line: 2},
+		{Product: "nodejs", Version: "18.19.0", Field: "nodejs", Path: ".tool-versions", Line: 2},
+		{Product: "go", Version: "1.22.1", Field: "golang", Path: ".tool-versions", Line: 3},
+		{Product: "eclipse-temurin", Version: "17.0.9+9", Field: "java", Path: ".tool-versions", Line: 4},
+		{Product: "kubernetes", Version: "1.29.2", Field: "kubectl", Path: ".tool-versions", Line: 7},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), components)
+	}
+	for i, c := range components {
+		if c != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], c)
+		}
+	}
+}
+
+func TestToolVersion(t *testing.T) {
+	tests := []struct {
+		tool, version   string
+		product, pinned string
+	}{
+		{tool: "java", version: "21", product: "openjdk-builds-from-oracle", pinned: "21"},
+		{tool: "java", version: "corretto-17.0.9.8.1", product: "amazon-corretto", pinned: "17.0.9.8.1"},
+		{tool: "core:node", version: "prefix:20", product: "nodejs", pinned: "20"},
+		{tool: "zig", version: "0.11.0", product: "zig", pinned: "0.11.0"},
+		{tool: "node", version: "lts"},
+		{tool: "python", version: "path:/opt/python"},
+		{tool: "java", version: "graalvm-community-21"},
+	}
+
+	for _, tt := range tests {
+		product, version, ok := toolVersion(tt.tool, tt.version)
+		if ok != (tt.product != "") || product != tt.product || version != tt.pinned {
+			t.Errorf("toolVersion(%q, %q): expected %q %q, got %q %q (ok %v)",
+				tt.tool, tt.version, tt.product, tt.pinned, product, version, ok)
+		}
+	}
+}
+
+func TestParseMiseToml(t *testing.T) {
+	data := []byte(`[env]
+NODE_ENV = "production"
+
+[tools]
+python = ["3.12", "3.8"]
+node = { version = "22", postinstall = "corepack enable" }
+"npm:prettier" = "3"
+"core:go" = "1.23"
+`)
+
+	components, err := ParseMiseToml("mise.toml", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Component{
+		{Product: "python", Version: "3.12", Field: "python", Path: "mise.toml", Line: 5},
+		{Product: "python", Version: "3.8", Field: "python", Path: "mise.toml", Line: 5},
+		{Product: "nodejs", Version: "22", Field: "node", Path: "mise.toml", Line: 6},
+		{Product: "go", Version: "1.23", Field: "core:go", Path: "mise.toml", Line: 8},
+	}
+	if len(components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), components)
+	}
+	for i, c := range components {
+		if c != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], c)
+		}
+	}
+
+	_, err = ParseMiseToml("mise.toml", []byte("[tools]\nnode = \"20\"\ngo = \n"))
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Path != "mise.toml" || pe.Line != 3 {
+		t.Errorf("expected parse error for invalid TOML on line 3, got %v", err)
+	}
+}
+
+func TestScanToolVersions(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		".tool-versions":        "nodejs 16.20.2\ncpython 3.12.4\nawscli 2.15.0\n",
+		"api/.mise.local.toml":  "[tools]\ngo = \"1.24\"\n",
+		"api/mise-settings.txt": "[tools]\ngo = \"1.11\"\n",
+		"web/.mise.toml":        "[tools]\ngo = \n",
+	})
+
+	findings, err := ScanToolVersions(context.Background(), testResolver(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 4 {
+		t.Fatalf("expected 4 findings, got %+v", findings)
+	}
+
+	if f := findings[0]; f.Err != nil || f.Release.Name != "16" || !f.IsEOL() {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if f := findings[1]; f.Err != nil || f.Product != "python" || f.Field != "cpython" || f.Release.Name != "3.12" {
+		t.Errorf("expected cpython to resolve by alias, got %+v", f)
+	}
+	// awscli matches no product and is skipped.
+	if f := findings[2]; f.Err != nil || f.Product != "go" || f.Release.Name != "1.24" {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if f := findings[3]; f.Err == nil || filepath.Base(filepath.Dir(f.Path)) != "web" || f.Line != 2 {
+		t.Errorf("expected a finding for the invalid .mise.toml, got %+v", f)
+	}
+}
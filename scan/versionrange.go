@@ -0,0 +1,378 @@
+package scan
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shmokmt/endoflife-go/internal/numver"
+)
+
+// Range is a set of versions allowed by a version constraint, such as the
+// engines.node field of package.json or the requires-python field of
+// pyproject.toml.
+type Range struct {
+	raw       string
+	intervals []interval // union of the allowed intervals
+}
+
+// interval is a range of versions. A nil bound is unbounded. Versions are
+// compared component by component, with missing components as zero.
+type interval struct {
+	lo, hi         []int
+	loExcl, hiIncl bool
+}
+
+// all is the interval of all versions.
+var all = interval{}
+
+// String returns the constraint the range was parsed from.
+func (r *Range) String() string {
+	return r.raw
+}
+
+// AllowsRelease reports whether the range allows any version of a release
+// cycle named like "18" or "3.12", that is of the versions from 3.12 up to,
+// but excluding, 3.13. It returns false for non-numeric names.
+func (r *Range) AllowsRelease(name string) bool {
+	parts, ok := numver.Parse(strings.TrimPrefix(strings.ToLower(name), "v"))
+	if !ok {
+		return false
+	}
+	cycle := interval{lo: parts, hi: bump(parts)}
+	for _, iv := range r.intervals {
+		if !iv.intersect(cycle).empty() {
+			return true
+		}
+	}
+	return false
+}
+
+// intersect returns the versions in both iv and other.
+func (iv interval) intersect(other interval) interval {
+	res := iv
+	if other.lo != nil {
+		if c := numver.Compare(other.lo, res.lo); res.lo == nil || c > 0 {
+			res.lo, res.loExcl = other.lo, other.loExcl
+		} else if c == 0 {
+			res.loExcl = res.loExcl || other.loExcl
+		}
+	}
+	if other.hi != nil {
+		if c := numver.Compare(other.hi, res.hi); res.hi == nil || c < 0 {
+			res.hi, res.hiIncl = other.hi, other.hiIncl
+		} else if c == 0 {
+			res.hiIncl = res.hiIncl && other.hiIncl
+		}
+	}
+	return res
+}
+
+// intersectSets returns the versions in both unions of intervals.
+func intersectSets(a, b []interval) []interval {
+	var res []interval
+	for _, x := range a {
+		for _, y := range b {
+			if iv := x.intersect(y); !iv.empty() {
+				res = append(res, iv)
+			}
+		}
+	}
+	return res
+}
+
+// empty reports whether the interval contains no version.
+func (iv interval) empty() bool {
+	if iv.lo == nil || iv.hi == nil {
+		return false
+	}
+	c := numver.Compare(iv.lo, iv.hi)
+	return c > 0 || (c == 0 && (iv.loExcl || !iv.hiIncl))
+}
+
+// bump returns the smallest version above all versions starting with
+// parts: 1.2 becomes 1.3.
+func bump(parts []int) []int {
+	if len(parts) == 0 {
+		return nil
+	}
+	res := append([]int(nil), parts...)
+	res[len(res)-1]++
+	return res
+}
+
+// npmOperatorSpace matches whitespace between an operator and its version,
+// as in ">= 14".
+var npmOperatorSpace = regexp.MustCompile(`(<=|>=|<|>|=|~>|~|\^)\s+`)
+
+// ParseNpmRange parses a range in the syntax of npm's semver package, such
+// as ">=14", "^18.17.0 || >=20", "18.x" or "16 - 20". Prerelease tags are
+// ignored.
+func ParseNpmRange(s string) (*Range, error) {
+	r := &Range{raw: s}
+	for _, alt := range strings.Split(s, "||") {
+		iv, err := parseNpmSet(strings.TrimSpace(alt))
+		if err != nil {
+			return nil, fmt.Errorf("invalid npm range %q: %w", s, err)
+		}
+		r.intervals = append(r.intervals, iv)
+	}
+	return r, nil
+}
+
+// parseNpmSet parses a hyphen range or a set of comparators that must all
+// be satisfied.
+func parseNpmSet(s string) (interval, error) {
+	if from, to, ok := strings.Cut(s, " - "); ok {
+		lo, err := parsePartial(strings.TrimSpace(from))
+		if err != nil {
+			return interval{}, err
+		}
+		hi, err := parsePartial(strings.TrimSpace(to))
+		if err != nil {
+			return interval{}, err
+		}
+		iv := interval{lo: lo}
+		if len(hi) == 3 {
+			iv.hi, iv.hiIncl = hi, true
+		} else {
+			iv.hi = bump(hi)
+		}
+		return iv, nil
+	}
+
+	res := all
+	for _, c := range strings.Fields(npmOperatorSpace.ReplaceAllString(s, "$1")) {
+		iv, err := parseNpmComparator(c)
+		if err != nil {
+			return interval{}, err
+		}
+		res = res.intersect(iv)
+	}
+	return res, nil
+}
+
+// parseNpmComparator parses a single comparator such as ">=14", "~1.2" or
+// "^0.2.3".
+func parseNpmComparator(c string) (interval, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", "~>", ">", "<", "=", "~", "^"} {
+		if rest, ok := strings.CutPrefix(c, prefix); ok {
+			op, c = prefix, rest
+			break
+		}
+	}
+	p, err := parsePartial(c)
+	if err != nil {
+		return interval{}, err
+	}
+
+	none := interval{lo: []int{0}, hi: []int{0}}
+	switch op {
+	case "", "=":
+		if len(p) == 0 {
+			return all, nil
+		}
+		if len(p) == 3 {
+			return interval{lo: p, hi: p, hiIncl: true}, nil
+		}
+		return interval{lo: p, hi: bump(p)}, nil
+	case ">=":
+		return interval{lo: p}, nil
+	case ">":
+		if len(p) == 0 {
+			return none, nil
+		}
+		if len(p) == 3 {
+			return interval{lo: p, loExcl: true}, nil
+		}
+		return interval{lo: bump(p)}, nil
+	case "<":
+		if len(p) == 0 {
+			return none, nil
+		}
+		return interval{hi: p}, nil
+	case "<=":
+		if len(p) == 0 {
+			return all, nil
+		}
+		if len(p) == 3 {
+			return interval{hi: p, hiIncl: true}, nil
+		}
+		return interval{hi: bump(p)}, nil
+	case "~", "~>":
+		if len(p) == 0 {
+			return all, nil
+		}
+		return interval{lo: p, hi: bump(p[:min(len(p), 2)])}, nil
+	default: // ^
+		if len(p) == 0 {
+			return all, nil
+		}
+		// Changes to the left-most non-zero component are not allowed.
+		i := 0
+		for i < len(p)-1 && p[i] == 0 {
+			i++
+		}
+		return interval{lo: p, hi: bump(p[:i+1])}, nil
+	}
+}
+
+// parsePartial parses a possibly partial version such as "1", "1.2.x" or
+// "v1.2.3-rc.1" into its numeric components, up to three. A wildcard (x, X
+// or *) ends the version.
+func parsePartial(v string) ([]int, error) {
+	v = strings.TrimPrefix(strings.TrimPrefix(v, "v"), "=")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	var parts []int
+	if v == "" {
+		return parts, nil
+	}
+	for _, f := range strings.Split(v, ".") {
+		if f == "x" || f == "X" || f == "*" {
+			break
+		}
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 || len(parts) == 3 {
+			return nil, fmt.Errorf("invalid version %q", v)
+		}
+		parts = append(parts, n)
+	}
+	return parts, nil
+}
+
+// ParsePEP440Range parses a version specifier as defined by PEP 440, such
+// as ">=3.8", "~=3.10" or ">=3.9, !=3.9.*, <4". Pre-, post- and
+// development release segments, epochs and local versions are ignored.
+func ParsePEP440Range(s string) (*Range, error) {
+	r := &Range{raw: s, intervals: []interval{all}}
+	for _, clause := range strings.Split(s, ",") {
+		set, err := parsePEP440Clause(strings.TrimSpace(clause))
+		if err != nil {
+			return nil, fmt.Errorf("invalid version specifier %q: %w", s, err)
+		}
+		r.intervals = intersectSets(r.intervals, set)
+	}
+	return r, nil
+}
+
+// pep440Operators are the comparison operators of PEP 440, longest first.
+var pep440Operators = []string{"===", "~=", "==", "!=", ">=", "<=", ">", "<"}
+
+// pep440Version matches the release segment of a PEP 440 version, after an
+// optional epoch, with an optional trailing wildcard.
+var pep440Version = regexp.MustCompile(`^v?(?:\d+!)?(\d+(?:\.\d+)*)(\.\*)?`)
+
+// parsePEP440Clause parses a single clause of a version specifier into a
+// union of intervals.
+func parsePEP440Clause(c string) ([]interval, error) {
+	op := ""
+	for _, prefix := range pep440Operators {
+		if rest, ok := strings.CutPrefix(c, prefix); ok {
+			op, c = prefix, strings.TrimSpace(rest)
+			break
+		}
+	}
+	if op == "" {
+		return nil, fmt.Errorf("missing operator in %q", c)
+	}
+	m := pep440Version.FindStringSubmatch(strings.ToLower(c))
+	if m == nil {
+		return nil, fmt.Errorf("invalid version %q", c)
+	}
+	v, _ := numver.Parse(m[1])
+	wildcard := m[2] != ""
+	if wildcard && op != "==" && op != "!=" {
+		return nil, fmt.Errorf("wildcard not allowed with %s", op)
+	}
+
+	switch op {
+	case "==", "===":
+		if wildcard {
+			return []interval{{lo: v, hi: bump(v)}}, nil
+		}
+		return []interval{{lo: v, hi: v, hiIncl: true}}, nil
+	case "!=":
+		if wildcard {
+			return []interval{{hi: v}, {lo: bump(v)}}, nil
+		}
+		return []interval{{hi: v}, {lo: v, loExcl: true}}, nil
+	case "~=":
+		if len(v) < 2 {
+			return nil, fmt.Errorf("~= requires at least two release components, got %q", c)
+		}
+		return []interval{{lo: v, hi: bump(v[:len(v)-1])}}, nil
+	case ">=":
+		return []interval{{lo: v}}, nil
+	case ">":
+		return []interval{{lo: v, loExcl: true}}, nil
+	case "<=":
+		return []interval{{hi: v, hiIncl: true}}, nil
+	default: // <
+		return []interval{{hi: v}}, nil
+	}
+}
+
+// ParseTerraformRange parses a version constraint of Terraform and its
+// providers, such as ">= 1.5.0", "~> 1.6" or ">= 1.3, < 2". A version
+// without an operator is exact. Pre-release suffixes are ignored.
+func ParseTerraformRange(s string) (*Range, error) {
+	r := &Range{raw: s, intervals: []interval{all}}
+	for _, clause := range strings.Split(s, ",") {
+		set, err := parseTerraformClause(strings.TrimSpace(clause))
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", s, err)
+		}
+		r.intervals = intersectSets(r.intervals, set)
+	}
+	return r, nil
+}
+
+// terraformOperators are the operators of Terraform version constraints,
+// longest first.
+var terraformOperators = []string{"~>", ">=", "<=", "!=", "=", ">", "<"}
+
+// terraformVersion matches the numeric part of a Terraform version.
+var terraformVersion = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)`)
+
+// parseTerraformClause parses a single clause of a version constraint into
+// a union of intervals.
+func parseTerraformClause(c string) ([]interval, error) {
+	op := "="
+	for _, prefix := range terraformOperators {
+		if rest, ok := strings.CutPrefix(c, prefix); ok {
+			op, c = prefix, strings.TrimSpace(rest)
+			break
+		}
+	}
+	m := terraformVersion.FindStringSubmatch(c)
+	if m == nil {
+		return nil, fmt.Errorf("invalid version %q", c)
+	}
+	v, _ := numver.Parse(m[1])
+
+	switch op {
+	case "~>":
+		// Only the rightmost component may increase: ~> 1.2 allows 1.x
+		// from 1.2, and ~> 1.2.0 allows 1.2.x.
+		if len(v) < 2 {
+			return []interval{{lo: v}}, nil
+		}
+		return []interval{{lo: v, hi: bump(v[:len(v)-1])}}, nil
+	case "=":
+		return []interval{{lo: v, hi: v, hiIncl: true}}, nil
+	case "!=":
+		return []interval{{hi: v}, {lo: v, loExcl: true}}, nil
+	case ">=":
+		return []interval{{lo: v}}, nil
+	case ">":
+		return []interval{{lo: v, loExcl: true}}, nil
+	case "<=":
+		return []interval{{hi: v, hiIncl: true}}, nil
+	default: // <
+		return []interval{{hi: v}}, nil
+	}
+}
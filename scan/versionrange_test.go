@@ -0,0 +1,203 @@
+package scan
+
+import (
+	"testing"
+)
+
+func TestParseNpmRange(t *testing.T) {
+	cycles := []string{"12", "14", "16", "18", "20", "22"}
+
+	tests := []struct {
+		rng     string
+		allowed []string
+	}{
+		{rng: ">=14", allowed: []string{"14", "16", "18", "20", "22"}},
+		{rng: ">= 16.13.0 <21", allowed: []string{"16", "18", "20"}},
+		{rng: ">14", allowed: []string{"16", "18", "20", "22"}},
+		{rng: ">14.0.0 <=18", allowed: []string{"14", "16", "18"}},
+		{rng: "<16", allowed: []string{"12", "14"}},
+		{rng: "^18.17.0 || >=20.3", allowed: []string{"18", "20", "22"}},
+		{rng: "~18.17", allowed: []string{"18"}},
+		{rng: "18.x", allowed: []string{"18"}},
+		{rng: "v20.11.1", allowed: []string{"20"}},
+		{rng: "14 - 18", allowed: []string{"14", "16", "18"}},
+		{rng: "14 - 18.0.0", allowed: []string{"14", "16", "18"}},
+		{rng: "*", allowed: cycles},
+		{rng: "", allowed: cycles},
+		{rng: ">=22.0.0-rc.1", allowed: []string{"22"}},
+		{rng: ">22 <20"},
+		{rng: ">*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rng, func(t *testing.T) {
+			r, err := ParseNpmRange(tt.rng)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var allowed []string
+			for _, c := range cycles {
+				if r.AllowsRelease(c) {
+					allowed = append(allowed, c)
+				}
+			}
+			if len(allowed) != len(tt.allowed) {
+				t.Fatalf("expected %v, got %v", tt.allowed, allowed)
+			}
+			for i := range allowed {
+				if allowed[i] != tt.allowed[i] {
+					t.Errorf("expected %v, got %v", tt.allowed, allowed)
+				}
+			}
+		})
+	}
+}
+
+func TestParseNpmRange_Caret(t *testing.T) {
+	tests := []struct {
+		rng     string
+		allowed string
+		denied  string
+	}{
+		{rng: "^1.2.3", allowed: "1.9", denied: "2.0"},
+		{rng: "^0.2.3", allowed: "0.2", denied: "0.3"},
+		{rng: "^0.0.3", allowed: "0.0.3", denied: "0.0.4"},
+		{rng: "^0.0", allowed: "0.0", denied: "0.1"},
+		{rng: "~1", allowed: "1.9", denied: "2.0"},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseNpmRange(tt.rng)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !r.AllowsRelease(tt.allowed) {
+			t.Errorf("expected %s to allow %s", tt.rng, tt.allowed)
+		}
+		if r.AllowsRelease(tt.denied) {
+			t.Errorf("expected %s to deny %s", tt.rng, tt.denied)
+		}
+	}
+}
+
+func TestParseNpmRange_Invalid(t *testing.T) {
+	for _, rng := range []string{"lts/*", ">=fourteen", "1.2.3.4"} {
+		if _, err := ParseNpmRange(rng); err == nil {
+			t.Errorf("expected error for %q", rng)
+		}
+	}
+
+	r, _ := ParseNpmRange(">=14")
+	if r.AllowsRelease("hydrogen") {
+		t.Error("expected non-numeric release to be denied")
+	}
+	if r.String() != ">=14" {
+		t.Errorf("expected >=14, got %s", r)
+	}
+}
+
+func TestParsePEP440Range(t *testing.T) {
+	cycles := []string{"2.7", "3.8", "3.9", "3.10", "3.11", "3.12", "3.13"}
+
+	tests := []struct {
+		spec    string
+		allowed []string
+	}{
+		{spec: ">=3.8", allowed: []string{"3.8", "3.9", "3.10", "3.11", "3.12", "3.13"}},
+		{spec: ">=3.9, <3.12", allowed: []string{"3.9", "3.10", "3.11"}},
+		{spec: ">=3.8,!=3.9.*,<3.11", allowed: []string{"3.8", "3.10"}},
+		{spec: "~=3.10", allowed: []string{"3.10", "3.11", "3.12", "3.13"}},
+		{spec: "~=3.10.2", allowed: []string{"3.10"}},
+		{spec: "==3.11.*", allowed: []string{"3.11"}},
+		{spec: "==3.12.4", allowed: []string{"3.12"}},
+		{spec: ">3.12", allowed: []string{"3.12", "3.13"}},
+		{spec: "<=3.8", allowed: []string{"2.7", "3.8"}},
+		{spec: "!=3.12.1", allowed: cycles},
+		{spec: ">=3.12rc1", allowed: []string{"3.12", "3.13"}},
+		{spec: "<3", allowed: []string{"2.7"}},
+		{spec: ">=4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			r, err := ParsePEP440Range(tt.spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var allowed []string
+			for _, c := range cycles {
+				if r.AllowsRelease(c) {
+					allowed = append(allowed, c)
+				}
+			}
+			if len(allowed) != len(tt.allowed) {
+				t.Fatalf("expected %v, got %v", tt.allowed, allowed)
+			}
+			for i := range allowed {
+				if allowed[i] != tt.allowed[i] {
+					t.Errorf("expected %v, got %v", tt.allowed, allowed)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePEP440Range_Invalid(t *testing.T) {
+	for _, spec := range []string{"3.8", ">=three", "~=3", ">=3.8.*", ""} {
+		if _, err := ParsePEP440Range(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestParseTerraformRange(t *testing.T) {
+	cycles := []string{"0.15", "1.5", "1.6", "1.9", "1.10", "2.0"}
+
+	tests := []struct {
+		constraint string
+		allowed    []string
+	}{
+		{constraint: ">= 1.5.0", allowed: []string{"1.5", "1.6", "1.9", "1.10", "2.0"}},
+		{constraint: "~> 1.6", allowed: []string{"1.6", "1.9", "1.10"}},
+		{constraint: "~> 1.6.0", allowed: []string{"1.6"}},
+		{constraint: "~> 1", allowed: []string{"1.5", "1.6", "1.9", "1.10", "2.0"}},
+		{constraint: ">= 1.3, < 2", allowed: []string{"1.5", "1.6", "1.9", "1.10"}},
+		{constraint: "1.9.8", allowed: []string{"1.9"}},
+		{constraint: "= 1.10.0-beta1", allowed: []string{"1.10"}},
+		{constraint: "!= 1.6.2", allowed: cycles},
+		{constraint: "> 1.9, <= 1.10.4", allowed: []string{"1.9", "1.10"}},
+		{constraint: "< 1", allowed: []string{"0.15"}},
+		{constraint: ">= 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			r, err := ParseTerraformRange(tt.constraint)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var allowed []string
+			for _, c := range cycles {
+				if r.AllowsRelease(c) {
+					allowed = append(allowed, c)
+				}
+			}
+			if len(allowed) != len(tt.allowed) {
+				t.Fatalf("expected %v, got %v", tt.allowed, allowed)
+			}
+			for i := range allowed {
+				if allowed[i] != tt.allowed[i] {
+					t.Errorf("expected %v, got %v", tt.allowed, allowed)
+				}
+			}
+		})
+	}
+}
+
+func TestParseTerraformRange_Invalid(t *testing.T) {
+	for _, constraint := range []string{">= one", "~>", "", ">= 1.5,"} {
+		if _, err := ParseTerraformRange(constraint); err == nil {
+			t.Errorf("expected error for %q", constraint)
+		}
+	}
+}
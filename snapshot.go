@@ -0,0 +1,317 @@
+package endoflife
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+)
+
+// Snapshot answers API queries from a local copy of the /products/full
+// endpoint, so that EOL checks work without network access. Its methods
+// mirror those of Client and return the same response types.
+type Snapshot struct {
+	full     FullProductListResponse
+	products map[string]int // product names and aliases to indexes in full.Result
+}
+
+// NewSnapshot creates a Snapshot from a /products/full response.
+func NewSnapshot(full *FullProductListResponse) *Snapshot {
+	s := &Snapshot{
+		full:     *full,
+		products: make(map[string]int),
+	}
+	for i, p := range s.full.Result {
+		for _, alias := range p.Aliases {
+			if _, ok := s.products[alias]; !ok {
+				s.products[alias] = i
+			}
+		}
+	}
+	// Product names take precedence over aliases.
+	for i, p := range s.full.Result {
+		s.products[p.Name] = i
+	}
+	return s
+}
+
+// ReadSnapshot reads a snapshot in the format of the /products/full endpoint.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var full FullProductListResponse
+	if err := json.NewDecoder(r).Decode(&full); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	return NewSnapshot(&full), nil
+}
+
+// LoadSnapshot reads a snapshot file.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer f.Close()
+	return ReadSnapshot(f)
+}
+
+// Write writes the snapshot in the format of the /products/full endpoint.
+func (s *Snapshot) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(&s.full)
+}
+
+// ExportSnapshot retrieves the full product list and writes it as a
+// snapshot that can later be read with ReadSnapshot or LoadSnapshot.
+func (c *Client) ExportSnapshot(ctx context.Context, w io.Writer) error {
+	full, err := c.GetProductsFull(ctx)
+	if err != nil {
+		return err
+	}
+	return NewSnapshot(full).Write(w)
+}
+
+// GetIndex returns the API index.
+func (s *Snapshot) GetIndex(ctx context.Context) (*URIListResponse, error) {
+	var uris []URI
+	for _, name := range []string{"products", "categories", "tags", "identifiers"} {
+		uris = append(uris, URI{Name: name, URI: snapshotURI(name)})
+	}
+	return s.uriList(uris), nil
+}
+
+// GetProducts returns a list of product summaries.
+func (s *Snapshot) GetProducts(ctx context.Context) (*ProductListResponse, error) {
+	return s.productList(func(*ProductDetails) bool { return true }), nil
+}
+
+// GetProductsFull returns a list of full product details.
+func (s *Snapshot) GetProductsFull(ctx context.Context) (*FullProductListResponse, error) {
+	full := s.full
+	full.Result = slices.Clone(s.full.Result)
+	full.Total = len(full.Result)
+	return &full, nil
+}
+
+// GetProduct returns detailed information for a specific product.
+func (s *Snapshot) GetProduct(ctx context.Context, productName string) (*ProductResponse, error) {
+	if productName == "" {
+		return nil, fmt.Errorf("product name is required")
+	}
+
+	p, err := s.product(productName)
+	if err != nil {
+		return nil, err
+	}
+	return &ProductResponse{
+		SchemaVersion: s.full.SchemaVersion,
+		GeneratedAt:   s.full.GeneratedAt,
+		Result:        *p,
+	}, nil
+}
+
+// GetRelease returns release information for a specific product release.
+func (s *Snapshot) GetRelease(ctx context.Context, productName, releaseName string) (*ProductReleaseResponse, error) {
+	if productName == "" {
+		return nil, fmt.Errorf("product name is required")
+	}
+	if releaseName == "" {
+		return nil, fmt.Errorf("release name is required")
+	}
+	if releaseName == "latest" {
+		return s.GetLatestRelease(ctx, productName)
+	}
+
+	p, err := s.product(productName)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range p.Releases {
+		if r.Name == releaseName {
+			return s.releaseResponse(r), nil
+		}
+	}
+	return nil, errSnapshotNotFound()
+}
+
+// GetLatestRelease returns the latest release information for a specific
+// product, which is the first release listed in the snapshot.
+func (s *Snapshot) GetLatestRelease(ctx context.Context, productName string) (*ProductReleaseResponse, error) {
+	if productName == "" {
+		return nil, fmt.Errorf("product name is required")
+	}
+
+	p, err := s.product(productName)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Releases) == 0 {
+		return nil, errSnapshotNotFound()
+	}
+	return s.releaseResponse(p.Releases[0]), nil
+}
+
+// GetCategories returns a list of categories.
+func (s *Snapshot) GetCategories(ctx context.Context) (*URIListResponse, error) {
+	var names []string
+	for _, p := range s.full.Result {
+		names = append(names, p.Category)
+	}
+	return s.uriList(namedURIs("categories", names)), nil
+}
+
+// GetCategoryProducts returns a list of products in a specific category.
+func (s *Snapshot) GetCategoryProducts(ctx context.Context, categoryName string) (*ProductListResponse, error) {
+	if categoryName == "" {
+		return nil, fmt.Errorf("category name is required")
+	}
+
+	result := s.productList(func(p *ProductDetails) bool { return p.Category == categoryName })
+	if result.Total == 0 {
+		return nil, errSnapshotNotFound()
+	}
+	return result, nil
+}
+
+// GetTags returns a list of tags.
+func (s *Snapshot) GetTags(ctx context.Context) (*URIListResponse, error) {
+	var names []string
+	for _, p := range s.full.Result {
+		names = append(names, p.Tags...)
+	}
+	return s.uriList(namedURIs("tags", names)), nil
+}
+
+// GetTagProducts returns a list of products with a specific tag.
+func (s *Snapshot) GetTagProducts(ctx context.Context, tagName string) (*ProductListResponse, error) {
+	if tagName == "" {
+		return nil, fmt.Errorf("tag name is required")
+	}
+
+	result := s.productList(func(p *ProductDetails) bool { return slices.Contains(p.Tags, tagName) })
+	if result.Total == 0 {
+		return nil, errSnapshotNotFound()
+	}
+	return result, nil
+}
+
+// GetIdentifiers returns a list of identifier types.
+func (s *Snapshot) GetIdentifiers(ctx context.Context) (*URIListResponse, error) {
+	var names []string
+	for _, p := range s.full.Result {
+		for _, id := range p.Identifiers {
+			names = append(names, id.Type)
+		}
+	}
+	return s.uriList(namedURIs("identifiers", names)), nil
+}
+
+// GetIdentifierDetails returns details for a specific identifier type.
+func (s *Snapshot) GetIdentifierDetails(ctx context.Context, identifierType string) (*IdentifierListResponse, error) {
+	if identifierType == "" {
+		return nil, fmt.Errorf("identifier type is required")
+	}
+
+	var mappings []IdentifierMapping
+	for _, p := range s.full.Result {
+		for _, id := range p.Identifiers {
+			if id.Type == identifierType {
+				mappings = append(mappings, IdentifierMapping{
+					Identifier: id.ID,
+					Product:    URI{Name: p.Name, URI: p.URI},
+				})
+			}
+		}
+	}
+	if len(mappings) == 0 {
+		return nil, errSnapshotNotFound()
+	}
+	return &IdentifierListResponse{
+		SchemaVersion: s.full.SchemaVersion,
+		GeneratedAt:   s.full.GeneratedAt,
+		Total:         len(mappings),
+		Result:        mappings,
+	}, nil
+}
+
+// product returns the product with the given name or alias.
+func (s *Snapshot) product(name string) (*ProductDetails, error) {
+	i, ok := s.products[name]
+	if !ok {
+		return nil, errSnapshotNotFound()
+	}
+	return &s.full.Result[i], nil
+}
+
+// productList returns summaries of the products matching keep.
+func (s *Snapshot) productList(keep func(*ProductDetails) bool) *ProductListResponse {
+	result := &ProductListResponse{
+		SchemaVersion: s.full.SchemaVersion,
+		GeneratedAt:   s.full.GeneratedAt,
+		Result:        []ProductSummary{},
+	}
+	for i := range s.full.Result {
+		p := &s.full.Result[i]
+		if !keep(p) {
+			continue
+		}
+		result.Result = append(result.Result, ProductSummary{
+			Name:     p.Name,
+			Label:    p.Label,
+			Aliases:  p.Aliases,
+			Category: p.Category,
+			Tags:     p.Tags,
+			URI:      p.URI,
+		})
+	}
+	result.Total = len(result.Result)
+	return result
+}
+
+func (s *Snapshot) releaseResponse(r ProductRelease) *ProductReleaseResponse {
+	return &ProductReleaseResponse{
+		SchemaVersion: s.full.SchemaVersion,
+		GeneratedAt:   s.full.GeneratedAt,
+		Result:        r,
+	}
+}
+
+func (s *Snapshot) uriList(uris []URI) *URIListResponse {
+	return &URIListResponse{
+		SchemaVersion: s.full.SchemaVersion,
+		GeneratedAt:   s.full.GeneratedAt,
+		Total:         len(uris),
+		Result:        uris,
+	}
+}
+
+// namedURIs returns sorted, deduplicated URIs for names under the given
+// endpoint.
+func namedURIs(endpoint string, names []string) []URI {
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	uris := []URI{}
+	for _, name := range names {
+		if name != "" {
+			uris = append(uris, URI{Name: name, URI: snapshotURI(endpoint, name)})
+		}
+	}
+	return uris
+}
+
+// snapshotURI returns the URI of an API endpoint.
+func snapshotURI(elem ...string) string {
+	uri, _ := url.JoinPath(DefaultBaseURL, elem...)
+	return uri
+}
+
+// errSnapshotNotFound returns the error the API returns for unknown resources.
+func errSnapshotNotFound() error {
+	return &APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    "resource not found",
+	}
+}
@@ -0,0 +1,205 @@
+package endoflife
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testSnapshot() *Snapshot {
+	return NewSnapshot(&FullProductListResponse{
+		SchemaVersion: "1.2.0",
+		Total:         2,
+		Result: []ProductDetails{
+			{
+				Name:        "python",
+				Label:       "Python",
+				Aliases:     []string{"cpython"},
+				Category:    "lang",
+				Tags:        []string{"lang", "python-software-foundation"},
+				URI:         "https://endoflife.date/api/v1/products/python",
+				Identifiers: []Identifier{{ID: "pkg:generic/python", Type: "purl"}},
+				Releases: []ProductRelease{
+					{Name: "3.13", Label: "3.13"},
+					{Name: "3.12", Label: "3.12"},
+				},
+			},
+			{
+				Name:        "ubuntu",
+				Label:       "Ubuntu",
+				Category:    "os",
+				Tags:        []string{"canonical", "linux-distribution", "os"},
+				URI:         "https://endoflife.date/api/v1/products/ubuntu",
+				Identifiers: []Identifier{{ID: "cpe:/o:canonical:ubuntu_linux", Type: "cpe"}},
+				Releases:    []ProductRelease{{Name: "24.04", Label: "24.04 'Noble Numbat' (LTS)"}},
+			},
+		},
+	})
+}
+
+func TestSnapshot_GetProduct(t *testing.T) {
+	s := testSnapshot()
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "by name", query: "python", expected: "python"},
+		{name: "by alias", query: "cpython", expected: "python"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.GetProduct(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Result.Name != tt.expected {
+				t.Errorf("expected product %s, got %s", tt.expected, result.Result.Name)
+			}
+			if result.SchemaVersion != "1.2.0" {
+				t.Errorf("expected schema_version 1.2.0, got %s", result.SchemaVersion)
+			}
+		})
+	}
+
+	if _, err := s.GetProduct(ctx, "nonexistent"); !IsNotFound(err) {
+		t.Errorf("expected NotFound error, got %v", err)
+	}
+	if _, err := s.GetProduct(ctx, ""); err == nil {
+		t.Error("expected error for empty product name")
+	}
+}
+
+func TestSnapshot_GetRelease(t *testing.T) {
+	s := testSnapshot()
+	ctx := context.Background()
+
+	release, err := s.GetRelease(ctx, "python", "3.12")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if release.Result.Name != "3.12" {
+		t.Errorf("expected release 3.12, got %s", release.Result.Name)
+	}
+
+	latest, err := s.GetLatestRelease(ctx, "python")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latest.Result.Name != "3.13" {
+		t.Errorf("expected latest release 3.13, got %s", latest.Result.Name)
+	}
+
+	if _, err := s.GetRelease(ctx, "python", "2.7"); !IsNotFound(err) {
+		t.Errorf("expected NotFound error, got %v", err)
+	}
+}
+
+func TestSnapshot_CategoriesAndTags(t *testing.T) {
+	s := testSnapshot()
+	ctx := context.Background()
+
+	categories, err := s.GetCategories(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if categories.Total != 2 || categories.Result[0].Name != "lang" {
+		t.Errorf("unexpected categories: %+v", categories.Result)
+	}
+
+	products, err := s.GetCategoryProducts(ctx, "os")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if products.Total != 1 || products.Result[0].Name != "ubuntu" {
+		t.Errorf("unexpected category products: %+v", products.Result)
+	}
+
+	tags, err := s.GetTags(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags.Total != 5 {
+		t.Errorf("expected 5 tags, got %d", tags.Total)
+	}
+
+	products, err = s.GetTagProducts(ctx, "lang")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if products.Total != 1 || products.Result[0].Name != "python" {
+		t.Errorf("unexpected tag products: %+v", products.Result)
+	}
+
+	if _, err := s.GetTagProducts(ctx, "nonexistent"); !IsNotFound(err) {
+		t.Errorf("expected NotFound error, got %v", err)
+	}
+}
+
+func TestSnapshot_Identifiers(t *testing.T) {
+	s := testSnapshot()
+	ctx := context.Background()
+
+	identifiers, err := s.GetIdentifiers(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if identifiers.Total != 2 {
+		t.Errorf("expected 2 identifier types, got %d", identifiers.Total)
+	}
+
+	details, err := s.GetIdentifierDetails(ctx, "cpe")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if details.Total != 1 {
+		t.Fatalf("expected 1 mapping, got %d", details.Total)
+	}
+	if details.Result[0].Identifier != "cpe:/o:canonical:ubuntu_linux" || details.Result[0].Product.Name != "ubuntu" {
+		t.Errorf("unexpected mapping: %+v", details.Result[0])
+	}
+}
+
+func TestExportSnapshot(t *testing.T) {
+	s := testSnapshot()
+	client, server := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/products/full" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		full, _ := s.GetProductsFull(context.Background())
+		json.NewEncoder(w).Encode(full)
+	})
+	defer server.Close()
+
+	var buf bytes.Buffer
+	if err := client.ExportSnapshot(context.Background(), &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	full, err := loaded.GetProductsFull(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if full.Total != 2 {
+		t.Errorf("expected 2 products, got %d", full.Total)
+	}
+	if _, err := loaded.GetRelease(context.Background(), "ubuntu", "24.04"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
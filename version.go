@@ -0,0 +1,54 @@
+package endoflife
+
+import (
+	"strings"
+
+	"github.com/shmokmt/endoflife-go/internal/numver"
+)
+
+// NormalizeVersion extracts the version number from a raw version string as
+// reported by tools and inventories, such as "python 3.12.4", "v20.11.1",
+// "17.0.9+9" or "22.04.3 LTS". It returns the leading run of digits and dots
+// of the first word starting with a digit (after an optional "v" prefix),
+// falling back to the first word containing a digit, as in "go1.24.4".
+// Only ASCII digits count as digits.
+// It returns "" if raw contains no version number.
+func NormalizeVersion(raw string) string {
+	fields := strings.Fields(raw)
+	for _, f := range fields {
+		f = strings.TrimPrefix(strings.TrimPrefix(f, "v"), "V")
+		if f != "" && numver.IsDigit(f[0]) {
+			return leadingVersion(f)
+		}
+	}
+	for _, f := range fields {
+		for i := 0; i < len(f); i++ {
+			if numver.IsDigit(f[i]) {
+				return leadingVersion(f[i:])
+			}
+		}
+	}
+	return ""
+}
+
+// leadingVersion returns the leading run of digits and dots of s, without
+// trailing dots.
+func leadingVersion(s string) string {
+	end := 0
+	for end < len(s) && (numver.IsDigit(s[end]) || s[end] == '.') {
+		end++
+	}
+	return strings.TrimRight(s[:end], ".")
+}
+
+// compareVersions compares two dotted numeric versions component by
+// component, treating missing components as zero. Non-numeric versions
+// compare as strings.
+func compareVersions(a, b string) int {
+	pa, okA := numver.Parse(a)
+	pb, okB := numver.Parse(b)
+	if !okA || !okB {
+		return strings.Compare(a, b)
+	}
+	return numver.Compare(pa, pb)
+}
@@ -0,0 +1,51 @@
+package endoflife
+
+import "testing"
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		{raw: "3.12.4", expected: "3.12.4"},
+		{raw: "python 3.12.4", expected: "3.12.4"},
+		{raw: "node v20.11.1", expected: "20.11.1"},
+		{raw: "openjdk 17.0.9+9", expected: "17.0.9"},
+		{raw: "ubuntu 22.04.3 LTS", expected: "22.04.3"},
+		{raw: "1.21rc2", expected: "1.21"},
+		{raw: "go1.24.4", expected: "1.24.4"},
+		{raw: "3.8-slim", expected: "3.8"},
+		{raw: "12.", expected: "12"},
+		{raw: "go\u0663 go1.24", expected: "1.24"},
+		{raw: "jammy", expected: ""},
+		{raw: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			result := NormalizeVersion(tt.raw)
+			if result != tt.expected {
+				t.Errorf("NormalizeVersion(%q) = %q, want %q", tt.raw, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "3.12.4", b: "3.12.4", expected: 0},
+		{a: "3.12.4", b: "3.12.10", expected: -1},
+		{a: "3.13", b: "3.12.10", expected: 1},
+		{a: "6", b: "6.0", expected: 0},
+		{a: "22.04", b: "22.4", expected: 0},
+	}
+
+	for _, tt := range tests {
+		if result := compareVersions(tt.a, tt.b); result != tt.expected {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, result, tt.expected)
+		}
+	}
+}